@@ -3,6 +3,8 @@ package main
 import ("fmt")
 
 func main(){
+	// weeks:expect-error-begin undefined: x
 	x = 5
+	// weeks:expect-error-end
 	fmt.Println("Hello World!")
 }
\ No newline at end of file
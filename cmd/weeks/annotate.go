@@ -0,0 +1,43 @@
+package main
+
+import (
+	"flag"
+	"os"
+
+	"example/Hello/internal/annotate"
+)
+
+var annotateOut string
+
+func init() {
+	commands = append(commands, &command{
+		name:    "annotate",
+		args:    "[-o file] day",
+		summary: "print a lesson with the inferred type of each declaration",
+		flags: func(fs *flag.FlagSet) {
+			fs.StringVar(&annotateOut, "o", "", "write the annotated lesson to `file`")
+		},
+		run: runAnnotate,
+	})
+}
+
+func runAnnotate(fs *flag.FlagSet) error {
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errFailed
+	}
+	lessons, err := selectLessons(fs.Args())
+	if err != nil {
+		return err
+	}
+	p, err := loadLesson(lessons[0])
+	if err != nil {
+		return err
+	}
+	src := annotate.Source(p, annotate.Find(p))
+	if annotateOut != "" {
+		return os.WriteFile(annotateOut, src, 0o644)
+	}
+	_, err = os.Stdout.Write(src)
+	return err
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"example/Hello/internal/claims"
+)
+
+func init() {
+	commands = append(commands, &command{
+		name:    "claims",
+		args:    "[day...]",
+		summary: "check that code in comments marked as not working still fails",
+		run:     runClaims,
+	})
+}
+
+func runClaims(fs *flag.FlagSet) error {
+	lessons, err := selectLessons(fs.Args())
+	if err != nil {
+		return err
+	}
+	failed := false
+	for _, l := range lessons {
+		p, err := loadLesson(l)
+		if err != nil {
+			return err
+		}
+		for _, c := range claims.Find(p) {
+			fmt.Printf("%s: %s\n", c.Pos, c.Comment)
+			fmt.Printf("  code:   %s\n", c.Code)
+			if c.Holds {
+				fmt.Printf("  holds:  fails with %s\n", strings.Join(quoteAll(c.Errors), ", "))
+			} else {
+				fmt.Println("  BROKEN: the code compiles, the note is out of date")
+				failed = true
+			}
+		}
+	}
+	if failed {
+		return errFailed
+	}
+	return nil
+}
+
+func quoteAll(s []string) []string {
+	q := make([]string, len(s))
+	for i, x := range s {
+		q[i] = fmt.Sprintf("%q", x)
+	}
+	return q
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"example/Hello/internal/check"
+	"example/Hello/internal/concepts"
+	"example/Hello/internal/lesson"
+)
+
+var (
+	conceptsFirst string
+	conceptsJSON  bool
+	conceptsList  bool
+)
+
+func init() {
+	commands = append(commands, &command{
+		name:    "concepts",
+		args:    "[-first concept] [-json] [-list] [day...]",
+		summary: "tag lessons with the Go features they use",
+		flags: func(fs *flag.FlagSet) {
+			fs.StringVar(&conceptsFirst, "first", "", "print the first lesson that uses `concept`")
+			fs.BoolVar(&conceptsJSON, "json", false, "print the inventory as JSON")
+			fs.BoolVar(&conceptsList, "list", false, "list the concepts the analyzer knows")
+		},
+		run: runConcepts,
+	})
+}
+
+func runConcepts(fs *flag.FlagSet) error {
+	if conceptsList {
+		names := make([]string, 0, len(concepts.Descriptions))
+		for name := range concepts.Descriptions {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, name := range names {
+			fmt.Fprintf(tw, "%s\t%s\n", name, concepts.Descriptions[name])
+		}
+		return tw.Flush()
+	}
+	if conceptsFirst != "" {
+		if _, ok := concepts.Descriptions[conceptsFirst]; !ok && !strings.Contains(conceptsFirst, ".") {
+			return fmt.Errorf("unknown concept %q (see weeks concepts -list)", conceptsFirst)
+		}
+	}
+
+	lessons, err := selectLessons(fs.Args())
+	if err != nil {
+		return err
+	}
+	type entry struct {
+		Lesson   string   `json:"lesson"`
+		Day      int      `json:"day"`
+		Concepts []string `json:"concepts"`
+		Std      []string `json:"std"`
+	}
+	var entries []entry
+	for _, l := range lessons {
+		inv, err := inventory(l)
+		if err != nil {
+			return err
+		}
+		if conceptsFirst != "" {
+			pos, ok := inv.First(conceptsFirst)
+			if !ok {
+				pos, ok = firstStd(inv, conceptsFirst)
+			}
+			if ok {
+				fmt.Printf("%s is first used on %s (%s)\n", conceptsFirst, l.Name(), pos)
+				return nil
+			}
+			continue
+		}
+		entries = append(entries, entry{l.Name(), l.Day, inv.Concepts(), inv.StdIdents()})
+	}
+	if conceptsFirst != "" {
+		fmt.Printf("%s is not used by any lesson\n", conceptsFirst)
+		return nil
+	}
+
+	if conceptsJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(entries)
+	}
+	for _, e := range entries {
+		fmt.Printf("%s\n  concepts: %s\n  std:      %s\n", e.Lesson, strings.Join(e.Concepts, ", "), strings.Join(e.Std, ", "))
+	}
+	return nil
+}
+
+func firstStd(inv concepts.Inventory, ident string) (pos token.Position, ok bool) {
+	for _, u := range inv.Std {
+		if u.Concept == ident {
+			return u.Pos, true
+		}
+	}
+	return pos, false
+}
+
+// loadLesson type-checks the program of l, naming it relative to the
+// module root in positions.
+func loadLesson(l lesson.Lesson) (*check.Package, error) {
+	src, err := os.ReadFile(l.Main())
+	if err != nil {
+		return nil, err
+	}
+	return check.Source(displayName(l), src), nil
+}
+
+// displayName names the program of l relative to the module root,
+// e.g. "Day 4/day4.go".
+func displayName(l lesson.Lesson) string {
+	return filepath.Join(l.Rel(), filepath.Base(l.Main()))
+}
+
+// inventory returns the concepts used by l.
+func inventory(l lesson.Lesson) (concepts.Inventory, error) {
+	p, err := loadLesson(l)
+	if err != nil {
+		return concepts.Inventory{}, err
+	}
+	return concepts.Analyze(p), nil
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"go/constant"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"example/Hello/internal/consteval"
+)
+
+var constEvalDay int
+
+func init() {
+	commands = append(commands, &command{
+		name:    "const-eval",
+		args:    "[-day n] [expr | :const name = expr]...",
+		summary: "evaluate constant expressions exactly, in the scope of a lesson",
+		flags: func(fs *flag.FlagSet) {
+			fs.IntVar(&constEvalDay, "day", 0, "evaluate in the package scope of this `day`")
+		},
+		run: runConstEval,
+	})
+}
+
+const constEvalHelp = `expr                evaluate a constant expression, e.g. PI*r*r
+:const name = expr  define a constant
+:consts             list the constants in scope
+:help               show this help
+:quit               leave
+`
+
+func runConstEval(fs *flag.FlagSet) error {
+	filename, src := "const-eval.go", []byte(nil)
+	if constEvalDay != 0 {
+		lessons, err := selectLessons([]string{fmt.Sprint(constEvalDay)})
+		if err != nil {
+			return err
+		}
+		src, err = os.ReadFile(lessons[0].Main())
+		if err != nil {
+			return err
+		}
+		filename = displayName(lessons[0])
+	}
+	s, err := consteval.New(filename, src)
+	if err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		failed := false
+		for _, line := range fs.Args() {
+			if !constEvalLine(os.Stdout, s, line) {
+				failed = true
+			}
+		}
+		if failed {
+			return errFailed
+		}
+		return nil
+	}
+
+	interactive := false
+	if fi, err := os.Stdin.Stat(); err == nil && fi.Mode()&os.ModeCharDevice != 0 {
+		interactive = true
+		fmt.Print("type :help for help\n> ")
+	}
+	sc := bufio.NewScanner(os.Stdin)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == ":quit" || line == ":q" {
+			break
+		}
+		if line != "" {
+			constEvalLine(os.Stdout, s, line)
+		}
+		if interactive {
+			fmt.Print("> ")
+		}
+	}
+	return sc.Err()
+}
+
+// constEvalLine runs one line of input and reports whether it succeeded.
+func constEvalLine(w io.Writer, s *consteval.Session, line string) bool {
+	cmd, rest, _ := strings.Cut(line, " ")
+	switch cmd {
+	case ":help":
+		fmt.Fprint(w, constEvalHelp)
+		return true
+	case ":consts":
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		for _, c := range s.Constants() {
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", c.Name(), c.Type(), c.Val())
+		}
+		tw.Flush()
+		return true
+	case ":const":
+		name, expr, ok := strings.Cut(rest, "=")
+		if !ok {
+			fmt.Fprintln(w, "usage: :const name = expr")
+			return false
+		}
+		if err := s.Define(strings.TrimSpace(name), strings.TrimSpace(expr)); err != nil {
+			fmt.Fprintln(w, "error:", err)
+			return false
+		}
+		return true
+	}
+	if strings.HasPrefix(cmd, ":") {
+		fmt.Fprintf(w, "unknown command %s; type :help for help\n", cmd)
+		return false
+	}
+	r, err := s.Eval(line)
+	if err != nil {
+		fmt.Fprintln(w, "error:", err)
+		return false
+	}
+	printConstResult(w, r)
+	return true
+}
+
+func printConstResult(w io.Writer, r *consteval.Result) {
+	fmt.Fprintf(w, "%s = %s\n", r.Expr, r.Value)
+	// Integral floats such as 1e400 would print every digit.
+	if exact := consteval.Exact(r.Value); exact != r.Value.String() && constant.ToInt(r.Value).Kind() != constant.Int {
+		fmt.Fprintf(w, "  exact:   %s\n", exact)
+	}
+	if r.Untyped() {
+		fmt.Fprintf(w, "  kind:    %s, default type %s\n", r.Type, r.Default)
+	} else {
+		fmt.Fprintf(w, "  type:    %s\n", r.Type)
+	}
+	if len(r.Conversions) > 0 {
+		fmt.Fprintln(w, "  converted:")
+		printConversions(w, r, r.Conversions)
+	}
+	if len(r.Typed) > 0 {
+		fmt.Fprintln(w, "  typed arithmetic, rounding after every operation:")
+		printConversions(w, r, r.Typed)
+	}
+}
+
+func printConversions(w io.Writer, r *consteval.Result, cs []consteval.Conversion) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, c := range cs {
+		switch {
+		case c.Err != "":
+			fmt.Fprintf(tw, "    %s\terror: %s\n", c.Expr, c.Err)
+		case c.Differs && strings.HasPrefix(c.Type, "int"):
+			fmt.Fprintf(tw, "    %s\t%s\tinteger arithmetic, unlike the untyped float constant\n", c.Expr, c.Value)
+		case c.Differs:
+			fmt.Fprintf(tw, "    %s\t%s\tfloating-point arithmetic, unlike the untyped integer constant\n", c.Expr, c.Value)
+		case c.Lost:
+			fmt.Fprintf(tw, "    %s\t%s\tprecision lost: holds %s, not %s\n", c.Expr, c.Value, consteval.Exact(c.Value), consteval.Exact(r.Value))
+		default:
+			fmt.Fprintf(tw, "    %s\t%s\n", c.Expr, c.Value)
+		}
+	}
+	tw.Flush()
+}
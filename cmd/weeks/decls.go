@@ -0,0 +1,94 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"example/Hello/internal/check"
+	"example/Hello/internal/decls"
+)
+
+var declsExpr string
+
+func init() {
+	commands = append(commands, &command{
+		name:    "decls",
+		args:    "[-e code] [day...]",
+		summary: "explain the var, := and const declarations of lessons or a snippet",
+		flags: func(fs *flag.FlagSet) {
+			fs.StringVar(&declsExpr, "e", "", "explain the declarations in `code`; - reads it from standard input")
+		},
+		run: runDecls,
+	})
+}
+
+func runDecls(fs *flag.FlagSet) error {
+	if declsExpr != "" {
+		if fs.NArg() > 0 {
+			fs.Usage()
+			return errFailed
+		}
+		src := declsExpr
+		if src == "-" {
+			b, err := io.ReadAll(os.Stdin)
+			if err != nil {
+				return err
+			}
+			src = string(b)
+		}
+		printDecls(decls.Snippet(src))
+		return nil
+	}
+	lessons, err := selectLessons(fs.Args())
+	if err != nil {
+		return err
+	}
+	for _, l := range lessons {
+		p, err := loadLesson(l)
+		if err != nil {
+			return err
+		}
+		printDecls(p)
+	}
+	return nil
+}
+
+func printDecls(p *check.Package) {
+	for _, d := range decls.Find(p) {
+		fmt.Printf("%s: %s\n", d.Pos, d.Code)
+		form := d.Form
+		if d.Grouped {
+			form = "grouped " + form
+		}
+		if d.InBlock {
+			form += " in a block"
+		}
+		fmt.Printf("  form:      %s\n", form)
+		fmt.Printf("  scope:     %s\n", d.Scope)
+		for _, n := range d.Names {
+			how := "given"
+			if n.Inferred {
+				how = "inferred"
+			}
+			fmt.Printf("  %-10s %s (%s)", n.Name+":", n.Type, how)
+			if n.Zero != "" {
+				fmt.Printf(", starts as %s", n.Zero)
+			}
+			fmt.Println()
+		}
+		for _, a := range d.Alternatives {
+			if a.Legal {
+				fmt.Printf("  legal:     %-30s %s\n", a.Code, a.Form)
+			}
+		}
+		for _, a := range d.Alternatives {
+			if !a.Legal {
+				fmt.Printf("  illegal:   %-30s %s\n", a.Code, strings.Join(quoteAll(a.Errors), ", "))
+			}
+		}
+		fmt.Println()
+	}
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"example/Hello/internal/concepts"
+	"example/Hello/internal/lesson"
+)
+
+var diffJSON bool
+
+func init() {
+	commands = append(commands, &command{
+		name:    "diff",
+		args:    "[-json] [[from] to]",
+		summary: "show which concepts are new, dropped or reused from one lesson to another",
+		flags: func(fs *flag.FlagSet) {
+			fs.BoolVar(&diffJSON, "json", false, "print the changes as JSON")
+		},
+		run: runDiff,
+	})
+}
+
+func runDiff(fs *flag.FlagSet) error {
+	if fs.NArg() > 2 {
+		fs.Usage()
+		return errFailed
+	}
+	all, err := selectLessons(nil)
+	if err != nil {
+		return err
+	}
+	days, err := parseDays(fs.Args())
+	if err != nil {
+		return err
+	}
+
+	// Pairs of lessons to compare: with no days, every lesson against
+	// the one before it; with one day, that lesson against the previous.
+	var pairs [][2]lesson.Lesson
+	switch len(days) {
+	case 0:
+		for i := 1; i < len(all); i++ {
+			pairs = append(pairs, [2]lesson.Lesson{all[i-1], all[i]})
+		}
+	case 1:
+		ls, err := lesson.Select(all, days)
+		if err != nil {
+			return err
+		}
+		prev, ok := previous(all, ls[0])
+		if !ok {
+			return fmt.Errorf("%s is the first lesson", ls[0].Name())
+		}
+		pairs = append(pairs, [2]lesson.Lesson{prev, ls[0]})
+	case 2:
+		ls, err := lesson.Select(all, days)
+		if err != nil {
+			return err
+		}
+		pairs = append(pairs, [2]lesson.Lesson{ls[0], ls[1]})
+	}
+
+	type entry struct {
+		From    string           `json:"from"`
+		To      string           `json:"to"`
+		Changes concepts.Changes `json:"changes"`
+	}
+	var entries []entry
+	for _, p := range pairs {
+		before, err := inventory(p[0])
+		if err != nil {
+			return err
+		}
+		after, err := inventory(p[1])
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry{p[0].Name(), p[1].Name(), concepts.Diff(before, after)})
+	}
+
+	if diffJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(entries)
+	}
+	for i, e := range entries {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("%s -> %s\n", e.From, e.To)
+		printDelta(os.Stdout, "features", e.Changes.Features)
+		printDelta(os.Stdout, "std", e.Changes.Std)
+		printDelta(os.Stdout, "forms", e.Changes.Forms)
+	}
+	return nil
+}
+
+// previous returns the lesson before l.
+func previous(all []lesson.Lesson, l lesson.Lesson) (lesson.Lesson, bool) {
+	for i := range all {
+		if all[i].Day == l.Day && i > 0 {
+			return all[i-1], true
+		}
+	}
+	return lesson.Lesson{}, false
+}
+
+func printDelta(w io.Writer, title string, d concepts.Delta) {
+	fmt.Fprintf(w, "  %s\n", title)
+	if len(d.New)+len(d.Dropped)+len(d.Reused) == 0 {
+		fmt.Fprintln(w, "    none")
+	}
+	for _, part := range []struct {
+		name  string
+		names []string
+	}{{"new", d.New}, {"dropped", d.Dropped}, {"reused", d.Reused}} {
+		if len(part.names) > 0 {
+			fmt.Fprintf(w, "    %-8s %s\n", part.name+":", strings.Join(part.names, ", "))
+		}
+	}
+}
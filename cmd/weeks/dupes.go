@@ -0,0 +1,95 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"example/Hello/internal/concepts"
+	"example/Hello/internal/similar"
+)
+
+var (
+	dupesThreshold float64
+	dupesAll       bool
+)
+
+func init() {
+	commands = append(commands, &command{
+		name:    "dupes",
+		args:    "[-threshold x] [-all] [day...]",
+		summary: "find lessons that repeat earlier ones",
+		flags: func(fs *flag.FlagSet) {
+			fs.Float64Var(&dupesThreshold, "threshold", 0.9, "similarity `score` from which two lessons count as duplicates")
+			fs.BoolVar(&dupesAll, "all", false, "print the score of every pair of lessons")
+		},
+		run: runDupes,
+	})
+}
+
+func runDupes(fs *flag.FlagSet) error {
+	lessons, err := selectLessons(fs.Args())
+	if err != nil {
+		return err
+	}
+	var prepared []similar.Lesson
+	// novel records the lessons that use a concept no earlier lesson used.
+	novel := make(map[int][]string)
+	seen := make(map[string]bool)
+	for _, l := range lessons {
+		p, err := loadLesson(l)
+		if err != nil {
+			return err
+		}
+		if p.File == nil {
+			continue
+		}
+		prepared = append(prepared, similar.Lesson{Day: l.Day, Tokens: similar.Tokens(p.Src), Shape: similar.Shape(p.File)})
+		for _, c := range concepts.Analyze(p).Concepts() {
+			if !seen[c] {
+				seen[c] = true
+				novel[l.Day] = append(novel[l.Day], c)
+			}
+		}
+	}
+
+	pairs := similar.Compare(prepared)
+	sort.SliceStable(pairs, func(i, j int) bool { return pairs[i].Score > pairs[j].Score })
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "PAIR\tSCORE\tTOKENS\tSHAPE\tVERDICT")
+	redundant := make(map[int]int)
+	for _, p := range pairs {
+		dup := p.Score >= dupesThreshold
+		if !dup && !dupesAll {
+			continue
+		}
+		verdict := ""
+		switch {
+		case dup && len(novel[p.B]) == 0:
+			verdict = fmt.Sprintf("Day %d adds nothing new", p.B)
+			if _, ok := redundant[p.B]; !ok {
+				redundant[p.B] = p.A
+			}
+		case dup:
+			verdict = fmt.Sprintf("similar, but Day %d introduces %s", p.B, strings.Join(novel[p.B], ", "))
+		}
+		fmt.Fprintf(tw, "Day %d / Day %d\t%.2f\t%.2f\t%.2f\t%s\n", p.A, p.B, p.Score, p.Tokens, p.Shape, verdict)
+	}
+	tw.Flush()
+
+	if len(redundant) > 0 {
+		days := make([]int, 0, len(redundant))
+		for d := range redundant {
+			days = append(days, d)
+		}
+		sort.Ints(days)
+		fmt.Println()
+		for _, d := range days {
+			fmt.Printf("Day %d repeats Day %d without a new concept\n", d, redundant[d])
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"example/Hello/internal/explain"
+	"example/Hello/internal/lesson"
+)
+
+func init() {
+	commands = append(commands, &command{
+		name:    "explain",
+		args:    "[day...]",
+		summary: "explain compiler errors in lessons in plain English",
+		run:     runExplain,
+	})
+}
+
+func runExplain(fs *flag.FlagSet) error {
+	lessons, err := selectLessons(fs.Args())
+	if err != nil {
+		return err
+	}
+	for _, l := range lessons {
+		if err := printExplanations(os.Stdout, l); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// printExplanations explains the errors of l, if it has any.
+func printExplanations(w io.Writer, l lesson.Lesson) error {
+	src, err := os.ReadFile(l.Main())
+	if err != nil {
+		return err
+	}
+	for _, x := range explain.File(displayName(l), src) {
+		fmt.Fprintf(w, "%s: %s\n", x.Pos, x.Message)
+		if x.Summary == "" {
+			continue
+		}
+		writeIndented(w, x.Summary)
+		if x.Fix != "" {
+			fmt.Fprintln(w, "  Try:")
+			for _, line := range dedent(x.Fix) {
+				fmt.Fprintln(w, "    "+line)
+			}
+			if x.Import != "" {
+				fmt.Fprintf(w, "  and add %q to the imports.\n", x.Import)
+			}
+		}
+		fmt.Fprintln(w)
+	}
+	return nil
+}
+
+// dedent splits s into lines and removes their common indentation.
+func dedent(s string) []string {
+	lines := strings.Split(s, "\n")
+	prefix := ""
+	for i, line := range lines {
+		indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		if i == 0 || strings.HasPrefix(prefix, indent) {
+			prefix = indent
+		}
+	}
+	for i, line := range lines {
+		lines[i] = strings.TrimPrefix(line, prefix)
+	}
+	return lines
+}
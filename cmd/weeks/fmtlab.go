@@ -0,0 +1,79 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"example/Hello/internal/check"
+	"example/Hello/internal/fmtlab"
+)
+
+var (
+	fmtlabDay  int
+	fmtlabVerb string
+)
+
+func init() {
+	commands = append(commands, &command{
+		name:    "fmtlab",
+		args:    "[-day n] [-verb v] value",
+		summary: "format a value with every applicable fmt verb, width, precision and flag",
+		flags: func(fs *flag.FlagSet) {
+			fs.IntVar(&fmtlabDay, "day", 0, "take value from the lesson of this `day`: a variable or constant whose\n"+
+				"value is known at compile time; the first declaration of the name in\n"+
+				"the file wins")
+			fs.StringVar(&fmtlabVerb, "verb", "", "only try this `verb`, e.g. x")
+		},
+		run: runFmtlab,
+	})
+}
+
+func runFmtlab(fs *flag.FlagSet) error {
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return errFailed
+	}
+	expr := strings.Join(fs.Args(), " ")
+	p := check.Source("fmtlab.go", []byte("package main\n"))
+	if fmtlabDay != 0 {
+		lessons, err := selectLessons([]string{fmt.Sprint(fmtlabDay)})
+		if err != nil {
+			return err
+		}
+		if p, err = loadLesson(lessons[0]); err != nil {
+			return err
+		}
+	}
+	v, found, err := fmtlab.FromLesson(p, expr)
+	if !found {
+		v, err = fmtlab.Literal(p, expr)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s (%s)\n", v.Expr, v.Type)
+	if v.Note != "" {
+		fmt.Printf("  note: %s\n", v.Note)
+	}
+	fmt.Println()
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "FORMAT\tOUTPUT")
+	for _, r := range fmtlab.Table(v.V, strings.TrimPrefix(fmtlabVerb, "%")) {
+		// Brackets show the padding.
+		fmt.Fprintf(tw, "%s\t[%s]\n", r.Format, r.Output)
+	}
+	tw.Flush()
+	fmt.Println()
+	fmt.Fprintln(tw, "CALL\tOUTPUT")
+	for _, r := range fmtlab.Spacing(v.V) {
+		fmt.Fprintf(tw, "%s\t[%s]\n", r.Format, r.Output)
+	}
+	tw.Flush()
+	fmt.Println("Println puts a space between all operands and ends the line;")
+	fmt.Println("Print only puts one between operands that are not strings.")
+	return nil
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"example/Hello/internal/journal"
+)
+
+var (
+	journalDay     int
+	journalMood    int
+	journalEnergy  int
+	journalMinutes int
+)
+
+func init() {
+	commands = append(commands, &command{
+		name:    "journal",
+		args:    "add [-day n] [-mood 1-5] [-energy 1-5] [-minutes n] text... | list [day...]",
+		summary: "keep a journal of mood, energy and time spent per lesson",
+		flags: func(fs *flag.FlagSet) {
+			fs.IntVar(&journalDay, "day", 0, "the `day` of the entry (default: the latest lesson)")
+			fs.IntVar(&journalMood, "mood", 0, "mood from 1 (low) to 5 (high)")
+			fs.IntVar(&journalEnergy, "energy", 0, "energy from 1 (low) to 5 (high)")
+			fs.IntVar(&journalMinutes, "minutes", 0, "minutes spent on the lesson")
+		},
+		run: runJournal,
+	})
+}
+
+func runJournal(fs *flag.FlagSet) error {
+	sub := fs.Arg(0)
+	// Flags may also follow the subcommand.
+	if err := fs.Parse(fs.Args()[min(1, fs.NArg()):]); err != nil {
+		return err
+	}
+	switch sub {
+	case "add":
+		text := strings.TrimSpace(strings.Join(fs.Args(), " "))
+		if text == "" {
+			return fmt.Errorf("journal add: missing text")
+		}
+		var args []string
+		if journalDay != 0 {
+			args = []string{strconv.Itoa(journalDay)}
+		}
+		lessons, err := selectLessons(args)
+		if err != nil {
+			return err
+		}
+		if len(lessons) == 0 {
+			return fmt.Errorf("no lessons")
+		}
+		l := lessons[len(lessons)-1]
+		e := journal.Entry{
+			Time:    time.Now().Truncate(time.Second),
+			Mood:    journalMood,
+			Energy:  journalEnergy,
+			Minutes: journalMinutes,
+			Text:    text,
+		}
+		if err := journal.Add(l, e); err != nil {
+			return err
+		}
+		fmt.Printf("added entry to %s\n", l.Name())
+		return nil
+	case "list":
+		lessons, err := selectLessons(fs.Args())
+		if err != nil {
+			return err
+		}
+		for _, l := range lessons {
+			entries, err := journal.Read(l)
+			if err != nil {
+				return err
+			}
+			for _, e := range entries {
+				fmt.Printf("%s  %s  %s\n", l.Name(), e.Time.Format("2006-01-02 15:04"), ratings(e))
+				writeIndented(os.Stdout, e.Text)
+			}
+		}
+		return nil
+	default:
+		fs.Usage()
+		return errFailed
+	}
+}
+
+func ratings(e journal.Entry) string {
+	var parts []string
+	if e.Mood > 0 {
+		parts = append(parts, fmt.Sprintf("mood %d/5", e.Mood))
+	}
+	if e.Energy > 0 {
+		parts = append(parts, fmt.Sprintf("energy %d/5", e.Energy))
+	}
+	if e.Minutes > 0 {
+		parts = append(parts, fmt.Sprintf("%d min", e.Minutes))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,156 @@
+// Command weeks works with the lessons of the 52 Weeks of Golang journal.
+//
+// Usage:
+//
+//	weeks <command> [flags] [args]
+//
+// Run "weeks help" for the list of commands.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"time"
+
+	"example/Hello/internal/runner"
+)
+
+// command is a weeks subcommand.
+type command struct {
+	name    string
+	args    string
+	summary string
+	flags   func(fs *flag.FlagSet)
+	run     func(fs *flag.FlagSet) error
+}
+
+var commands []*command
+
+// errFailed is returned by commands that already reported their failure.
+var errFailed = errors.New("failed")
+
+func main() {
+	runner.Init()
+
+	log := func(err error) {
+		fmt.Fprintln(os.Stderr, "weeks:", err)
+	}
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	name, args := os.Args[1], os.Args[2:]
+	if name == "help" || name == "-h" || name == "--help" {
+		usage()
+		return
+	}
+	for _, c := range commands {
+		if c.name != name {
+			continue
+		}
+		fs := flag.NewFlagSet("weeks "+c.name, flag.ExitOnError)
+		fs.Usage = func() {
+			fmt.Fprintf(fs.Output(), "usage: weeks %s %s\n\n%s\n\n", c.name, c.args, c.summary)
+			fs.PrintDefaults()
+		}
+		if c.flags != nil {
+			c.flags(fs)
+		}
+		fs.Parse(args)
+		if err := c.run(fs); err != nil {
+			if err != errFailed {
+				log(err)
+			}
+			os.Exit(1)
+		}
+		return
+	}
+	log(fmt.Errorf("unknown command %q", name))
+	usage()
+	os.Exit(2)
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: weeks <command> [flags] [args]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "commands:")
+	for _, c := range commands {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", c.name, c.summary)
+	}
+}
+
+// findRoot returns the directory of the enclosing go.mod.
+func findRoot() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", errors.New("go.mod not found")
+		}
+		dir = parent
+	}
+}
+
+// parseDays converts command line arguments into day numbers.
+func parseDays(args []string) ([]int, error) {
+	var days []int
+	for _, a := range args {
+		d, err := strconv.Atoi(a)
+		if err != nil || d < 1 {
+			return nil, fmt.Errorf("invalid day %q", a)
+		}
+		days = append(days, d)
+	}
+	return days, nil
+}
+
+// Settings shared by the commands that run lessons.
+var (
+	limitTimeout  time.Duration
+	limitMemoryMB uint64
+	limitOutput   int64
+	buildWorkers  int
+	noCache       bool
+)
+
+func runFlags(fs *flag.FlagSet) {
+	fs.DurationVar(&limitTimeout, "timeout", 10*time.Second, "kill a lesson after this wall-clock `duration` (0 disables)")
+	fs.Uint64Var(&limitMemoryMB, "memory", 512, "cap the memory of a lesson at this many `MiB` (0 disables)")
+	fs.Int64Var(&limitOutput, "max-output", 1<<20, "stop a lesson after this many `bytes` of output (0 disables)")
+	fs.IntVar(&buildWorkers, "j", runtime.GOMAXPROCS(0), "number of lessons to build in parallel")
+	fs.BoolVar(&noCache, "nocache", false, "rebuild every lesson instead of using "+cacheDir)
+}
+
+// cacheDir holds compiled lessons, relative to the module root.
+const cacheDir = ".weeks/cache"
+
+// newRunner returns a runner configured from the command line.
+func newRunner() (*runner.Runner, error) {
+	r := &runner.Runner{
+		Limits: runner.Limits{
+			Timeout: limitTimeout,
+			Memory:  limitMemoryMB << 20,
+			Output:  limitOutput,
+		},
+		Workers: buildWorkers,
+	}
+	if !noCache {
+		root, err := findRoot()
+		if err != nil {
+			return nil, err
+		}
+		r.CacheDir = filepath.Join(root, cacheDir)
+	}
+	return r, nil
+}
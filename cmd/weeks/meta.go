@@ -0,0 +1,104 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"example/Hello/internal/lesson"
+	"example/Hello/internal/meta"
+)
+
+var (
+	metaLint   bool
+	metaTopic  string
+	metaStatus string
+)
+
+func init() {
+	commands = append(commands, &command{
+		name:    "meta",
+		args:    "[-lint] [-topic t] [-status s] [day...]",
+		summary: "list, filter and lint lesson.toml metadata",
+		flags: func(fs *flag.FlagSet) {
+			fs.BoolVar(&metaLint, "lint", false, "check every lesson.toml against the schema")
+			fs.StringVar(&metaTopic, "topic", "", "only show lessons with this `topic`")
+			fs.StringVar(&metaStatus, "status", "", "only show lessons with this `status`")
+		},
+		run: runMeta,
+	})
+}
+
+func runMeta(fs *flag.FlagSet) error {
+	root, err := findRoot()
+	if err != nil {
+		return err
+	}
+	all, err := lesson.Discover(root)
+	if err != nil {
+		return err
+	}
+	lessons, err := selectLessons(fs.Args())
+	if err != nil {
+		return err
+	}
+	days := make(map[int]bool, len(all))
+	for _, l := range all {
+		days[l.Day] = true
+	}
+
+	failed := false
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	if !metaLint {
+		fmt.Fprintln(tw, "LESSON\tTITLE\tSTATUS\tMINUTES\tTOPICS")
+	}
+	for _, l := range lessons {
+		m, ok, err := meta.Read(l)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", relPath(root, meta.Path(l)), err)
+			failed = true
+			continue
+		}
+		if metaLint {
+			if !ok {
+				continue
+			}
+			for _, p := range m.Lint(l.Day, days) {
+				fmt.Printf("%s: %s\n", relPath(root, meta.Path(l)), p)
+				failed = true
+			}
+			continue
+		}
+		if !ok {
+			if metaTopic == "" && metaStatus == "" {
+				fmt.Fprintf(tw, "%s\t-\t-\t-\t-\n", l.Name())
+			}
+			continue
+		}
+		if metaTopic != "" && !m.HasTopic(metaTopic) || metaStatus != "" && string(m.Status) != metaStatus {
+			continue
+		}
+		minutes := "-"
+		if m.Minutes > 0 {
+			minutes = strconv.Itoa(m.Minutes)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", l.Name(), m.Title, m.Status, minutes, strings.Join(m.Topics, ", "))
+	}
+	tw.Flush()
+	if failed {
+		return errFailed
+	}
+	return nil
+}
+
+// relPath returns path relative to root for display.
+func relPath(root, path string) string {
+	if r, err := filepath.Rel(root, path); err == nil {
+		return r
+	}
+	return path
+}
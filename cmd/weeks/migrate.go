@@ -0,0 +1,102 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"example/Hello/internal/migrate"
+	"example/Hello/internal/notes"
+)
+
+var (
+	migrateDryRun   bool
+	migrateModCache string
+)
+
+func init() {
+	commands = append(commands, &command{
+		name:    "migrate",
+		args:    "[-dry-run] [-move-modcache dir]",
+		summary: "move the Day N lessons to days/dayNN so that go build ./... sees them",
+		flags: func(fs *flag.FlagSet) {
+			fs.BoolVar(&migrateDryRun, "dry-run", false, "only show what would be moved")
+			fs.StringVar(&migrateModCache, "move-modcache", "", "also move the module cache in pkg/mod out of the module to `dir`")
+		},
+		run: runMigrate,
+	})
+}
+
+func runMigrate(fs *flag.FlagSet) error {
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return errFailed
+	}
+	root, err := findRoot()
+	if err != nil {
+		return err
+	}
+	moves, err := migrate.Plan(root)
+	if err != nil {
+		return err
+	}
+	if len(moves) == 0 {
+		fmt.Println("all lessons are already migrated")
+	}
+	verb := "moved"
+	if migrateDryRun {
+		verb = "would move"
+	}
+	for _, m := range moves {
+		fmt.Printf("%s %s -> %s\n", verb, m.From, m.To)
+	}
+	if !migrateDryRun {
+		if err := migrate.Apply(root, moves); err != nil {
+			return err
+		}
+	}
+	n, err := migrate.RewriteNotes(filepath.Join(root, notes.FileName), moves, migrateDryRun)
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		verb := "updated"
+		if migrateDryRun {
+			verb = "would update"
+		}
+		fmt.Printf("%s %d file names in %s\n", verb, n, notes.FileName)
+	}
+	if migrateModCache != "" && migrate.HasModCache(root) {
+		if migrateDryRun {
+			fmt.Printf("would move %s -> %s\n", migrate.ModCache, migrateModCache)
+		} else {
+			if err := migrate.MoveModCache(root, migrateModCache); err != nil {
+				return err
+			}
+			fmt.Printf("moved %s -> %s\n", migrate.ModCache, migrateModCache)
+		}
+	}
+	warnings, err := migrate.Warnings(root)
+	if err != nil {
+		return err
+	}
+	if migrateDryRun && migrateModCache != "" {
+		// The cache is still in place, but would not be.
+		warnings = slices.DeleteFunc(warnings, func(w string) bool { return strings.HasPrefix(w, migrate.ModCache+" ") })
+	}
+	for _, w := range warnings {
+		fmt.Fprintln(os.Stderr, "weeks: warning:", w)
+	}
+	switch {
+	case len(warnings) > 0:
+		fmt.Println("go build ./... will still fail; see the warnings above")
+	case migrateDryRun:
+		fmt.Println("go build ./... would then see every lesson")
+	default:
+		fmt.Println("go build ./... now sees every lesson")
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"example/Hello/internal/lesson"
+	"example/Hello/internal/scaffold"
+)
+
+var newOpts scaffold.Options
+
+func init() {
+	commands = append(commands, &command{
+		name:    "new",
+		args:    "[-golden] [-exercise] [-meta] [-title t] [day]",
+		summary: "create the next lesson from a template",
+		flags: func(fs *flag.FlagSet) {
+			fs.BoolVar(&newOpts.Golden, "golden", false, "also create the golden output file")
+			fs.BoolVar(&newOpts.Exercise, "exercise", false, "also create an exercise.md stub")
+			fs.BoolVar(&newOpts.Meta, "meta", false, "also create a lesson.toml metadata file")
+			fs.StringVar(&newOpts.Title, "title", "", "title of the lesson, used by -meta")
+		},
+		run: runNew,
+	})
+}
+
+func runNew(fs *flag.FlagSet) error {
+	if fs.NArg() > 1 {
+		fs.Usage()
+		return errFailed
+	}
+	root, err := findRoot()
+	if err != nil {
+		return err
+	}
+	lessons, err := lesson.Discover(root)
+	if err != nil {
+		return err
+	}
+	next := lesson.Next(lessons)
+	day := next
+	if fs.NArg() == 1 {
+		days, err := parseDays(fs.Args())
+		if err != nil {
+			return err
+		}
+		day = days[0]
+	}
+	if day > next {
+		var skipped []string
+		for d := next; d < day; d++ {
+			skipped = append(skipped, strconv.Itoa(d))
+		}
+		fmt.Fprintf(os.Stderr, "weeks: warning: skipping day %s\n", strings.Join(skipped, ", "))
+	}
+
+	files, err := scaffold.Create(lesson.At(root, day), newOpts)
+	for _, f := range files {
+		rel, _ := filepath.Rel(root, f)
+		fmt.Println("created", rel)
+	}
+	return err
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"example/Hello/internal/lesson"
+	"example/Hello/internal/notes"
+)
+
+var (
+	notesDay   int
+	notesTopic string
+)
+
+func init() {
+	commands = append(commands, &command{
+		name:    "notes",
+		args:    "build | search [-day n] [-topic t] [query...]",
+		summary: "collect the notes in lesson comments and search them",
+		flags: func(fs *flag.FlagSet) {
+			fs.IntVar(&notesDay, "day", 0, "only search the notes of this `day`")
+			fs.StringVar(&notesTopic, "topic", "", "only search notes about this `topic`")
+		},
+		run: runNotes,
+	})
+}
+
+func runNotes(fs *flag.FlagSet) error {
+	root, err := findRoot()
+	if err != nil {
+		return err
+	}
+	kb := filepath.Join(root, notes.FileName)
+	switch fs.Arg(0) {
+	case "build":
+		lessons, err := lesson.Discover(root)
+		if err != nil {
+			return err
+		}
+		all := []notes.Note{}
+		for _, l := range lessons {
+			p, err := loadLesson(l)
+			if err != nil {
+				return err
+			}
+			all = append(all, notes.Extract(l.Day, p)...)
+		}
+		if err := notes.Save(kb, all); err != nil {
+			return err
+		}
+		fmt.Printf("wrote %d notes to %s\n", len(all), notes.FileName)
+		return nil
+	case "search":
+		// Flags may also follow the subcommand.
+		if err := fs.Parse(fs.Args()[1:]); err != nil {
+			return err
+		}
+		all, err := notes.Load(kb)
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("%s not found; run weeks notes build first", notes.FileName)
+		}
+		if err != nil {
+			return err
+		}
+		found := notes.Search(all, strings.Join(fs.Args(), " "), notesDay, notesTopic)
+		for i, n := range found {
+			if i > 0 {
+				fmt.Println()
+			}
+			fmt.Printf("%s:%d [%s]\n", n.File, n.Line, n.Topic)
+			writeIndented(os.Stdout, n.Text)
+			if n.Construct != "" {
+				fmt.Printf("  -> %s\n", n.Construct)
+			}
+		}
+		if len(found) == 0 {
+			fmt.Println("no notes found")
+		}
+		return nil
+	default:
+		fs.Usage()
+		return errFailed
+	}
+}
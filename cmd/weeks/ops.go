@@ -0,0 +1,105 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"example/Hello/internal/ops"
+)
+
+var (
+	opsDay  int
+	opsLine int
+)
+
+func init() {
+	commands = append(commands, &command{
+		name:    "ops",
+		args:    "op [type [type]] | -day n [-line l]",
+		summary: "show what an operator does with operands of the basic types",
+		flags: func(fs *flag.FlagSet) {
+			fs.IntVar(&opsDay, "day", 0, "explain the expressions in the lesson of this `day`")
+			fs.IntVar(&opsLine, "line", 0, "only the expressions on this `line`")
+		},
+		run: runOps,
+	})
+}
+
+func runOps(fs *flag.FlagSet) error {
+	if opsLine != 0 && opsDay == 0 {
+		return fmt.Errorf("-line needs -day")
+	}
+	if opsDay != 0 {
+		if fs.NArg() > 0 {
+			fs.Usage()
+			return errFailed
+		}
+		lessons, err := selectLessons([]string{fmt.Sprint(opsDay)})
+		if err != nil {
+			return err
+		}
+		p, err := loadLesson(lessons[0])
+		if err != nil {
+			return err
+		}
+		for _, r := range ops.InFile(p, opsLine) {
+			fmt.Printf("%s: %s\n", r.Pos, r.Expr)
+			printOp(r)
+		}
+		return nil
+	}
+
+	var pairs [][2]string
+	switch fs.NArg() {
+	case 1:
+		for _, t := range ops.Types {
+			pairs = append(pairs, [2]string{t, t})
+		}
+	case 2:
+		for _, t := range ops.Types {
+			pairs = append(pairs, [2]string{fs.Arg(1), t})
+		}
+	case 3:
+		pairs = append(pairs, [2]string{fs.Arg(1), fs.Arg(2)})
+	default:
+		fs.Usage()
+		return errFailed
+	}
+	if len(pairs) == 1 {
+		r, err := ops.Check(fs.Arg(0), pairs[0][0], pairs[0][1])
+		if err != nil {
+			return err
+		}
+		fmt.Println(r.Expr)
+		printOp(r)
+		return nil
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "LEFT\tRIGHT\tRESULT\tNOTE")
+	for _, p := range pairs {
+		r, err := ops.Check(fs.Arg(0), p[0], p[1])
+		if err != nil {
+			return err
+		}
+		if r.OK() {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", r.X, r.Y, r.Type, ops.Note(r))
+		} else {
+			fmt.Fprintf(tw, "%s\t%s\t-\t%s\n", r.X, r.Y, r.Err)
+		}
+	}
+	return tw.Flush()
+}
+
+func printOp(r ops.Result) {
+	fmt.Printf("  operands: %s %s %s\n", r.X, r.Op, r.Y)
+	if !r.OK() {
+		fmt.Printf("  error:    %s\n", r.Err)
+		return
+	}
+	fmt.Printf("  result:   %s\n", r.Type)
+	if note := ops.Note(r); note != "" {
+		fmt.Printf("  note:     %s\n", note)
+	}
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"example/Hello/internal/config"
+	"example/Hello/internal/journal"
+	"example/Hello/internal/lesson"
+	"example/Hello/internal/meta"
+	"example/Hello/internal/substance"
+	"example/Hello/internal/syllabus"
+)
+
+var progressJSON bool
+
+func init() {
+	commands = append(commands, &command{
+		name:    "progress",
+		args:    "[-json]",
+		summary: "compare the lessons with the 52-week syllabus",
+		flags: func(fs *flag.FlagSet) {
+			fs.BoolVar(&progressJSON, "json", false, "print the report as JSON")
+		},
+		run: runProgress,
+	})
+}
+
+func runProgress(fs *flag.FlagSet) error {
+	root, err := findRoot()
+	if err != nil {
+		return err
+	}
+	s, err := syllabus.Load(filepath.Join(root, syllabus.FileName))
+	if err != nil {
+		return err
+	}
+	lessons, err := lesson.Discover(root)
+	if err != nil {
+		return err
+	}
+	practiced := make(map[int][]string, len(lessons))
+	for _, l := range lessons {
+		m, ok, err := meta.Read(l)
+		if err != nil {
+			return err
+		}
+		practiced[l.Day] = nil
+		if ok {
+			practiced[l.Day] = m.Topics
+		}
+	}
+	cfg, err := config.Load(root)
+	if err != nil {
+		return err
+	}
+	scores, err := substanceScores(lessons)
+	if err != nil {
+		return err
+	}
+	r := progressReport{
+		Report:  s.Compare(practiced),
+		Thin:    thinDays(scores, cfg),
+		Journal: []dayJournal{},
+	}
+	thin := make(map[int]bool, len(r.Thin))
+	for _, t := range r.Thin {
+		thin[t.Day] = true
+	}
+	for _, l := range lessons {
+		entries, err := journal.Read(l)
+		if err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			continue
+		}
+		s := journal.Summarize(l.Day, entries)
+		r.Journal = append(r.Journal, dayJournal{
+			Summary:    s,
+			LowAndThin: s.LowEnergy() && thin[l.Day],
+		})
+	}
+
+	if progressJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(r)
+	}
+	fmt.Printf("%d lessons, up to day %d (week %d of %d)\n", r.Lessons, r.LastDay, syllabus.WeekOf(r.LastDay), syllabus.Weeks)
+	fmt.Printf("topic coverage: %.0f%% of the topics planned so far\n", 100*r.Coverage)
+	fmt.Println()
+	fmt.Printf("missing days: %s\n", joinDays(r.Missing))
+	fmt.Printf("days without a planned topic: %s\n", joinDays(r.Unplanned))
+	fmt.Println("planned but not practiced:")
+	if len(r.Unpracticed) == 0 {
+		fmt.Println("  none")
+	}
+	for _, p := range r.Unpracticed {
+		if p.Day == 0 {
+			fmt.Printf("  week %-2d %s\n", p.Week, p.Topic)
+		} else {
+			fmt.Printf("  day %-3d %s\n", p.Day, p.Topic)
+		}
+	}
+	fmt.Printf("thin days (substance score below %.0f):\n", cfg.Score.Min)
+	if len(r.Thin) == 0 {
+		fmt.Println("  none")
+	}
+	for _, t := range r.Thin {
+		fmt.Printf("  day %-3d score %.0f\n", t.Day, t.Value)
+	}
+	fmt.Println("journal:")
+	if len(r.Journal) == 0 {
+		fmt.Println("  no entries")
+	}
+	for _, j := range r.Journal {
+		fmt.Printf("  day %-3d %d entries, %d min", j.Day, j.Entries, j.Minutes)
+		if j.Mood > 0 {
+			fmt.Printf(", mood %.1f", j.Mood)
+		}
+		if j.Energy > 0 {
+			fmt.Printf(", energy %.1f", j.Energy)
+		}
+		if j.LowAndThin {
+			fmt.Print("  (low energy, thin lesson)")
+		}
+		fmt.Println()
+	}
+	return nil
+}
+
+// progressReport is the syllabus report with the thin days and the
+// journal added.
+type progressReport struct {
+	syllabus.Report
+	Thin    []substance.Score `json:"thin_days"`
+	Journal []dayJournal      `json:"journal"`
+}
+
+// dayJournal summarizes the journal of a day. LowAndThin links a day of
+// low energy with a thin lesson.
+type dayJournal struct {
+	journal.Summary
+	LowAndThin bool `json:"low_energy_thin"`
+}
+
+func joinDays(days []int) string {
+	if len(days) == 0 {
+		return "none"
+	}
+	s := make([]string, len(days))
+	for i, d := range days {
+		s[i] = strconv.Itoa(d)
+	}
+	return strings.Join(s, ", ")
+}
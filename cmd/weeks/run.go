@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"example/Hello/internal/lesson"
+	"example/Hello/internal/report"
+	"example/Hello/internal/runner"
+)
+
+var (
+	runFormat string
+	runOutput string
+)
+
+func init() {
+	commands = append(commands, &command{
+		name:    "run",
+		args:    "[-format f] [-o file] [day...]",
+		summary: "build and run lessons and print a summary",
+		flags: func(fs *flag.FlagSet) {
+			fs.StringVar(&runFormat, "format", "text", "report `format`: text, json, junit or tap")
+			fs.StringVar(&runOutput, "o", "", "write the report to `file` instead of stdout")
+			runFlags(fs)
+		},
+		run: runRun,
+	})
+}
+
+var reportWriters = map[string]func(io.Writer, []report.Record) error{
+	"json":  report.JSON,
+	"junit": report.JUnit,
+	"tap":   report.TAP,
+}
+
+func runRun(fs *flag.FlagSet) error {
+	writeReport, ok := reportWriters[runFormat]
+	if !ok && runFormat != "text" {
+		return fmt.Errorf("unknown format %q", runFormat)
+	}
+	lessons, err := selectLessons(fs.Args())
+	if err != nil {
+		return err
+	}
+	r, err := newRunner()
+	if err != nil {
+		return err
+	}
+	results := r.RunAll(context.Background(), lessons)
+
+	var w io.Writer = os.Stdout
+	if runOutput != "" {
+		f, err := os.Create(runOutput)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	if writeReport != nil {
+		root, err := findRoot()
+		if err != nil {
+			return err
+		}
+		if err := writeReport(w, report.Records(root, results)); err != nil {
+			return err
+		}
+	} else {
+		printSummary(w, results)
+		for _, res := range results {
+			if res.Status == runner.StatusBuildFailed {
+				fmt.Fprintf(w, "\n== %s explained\n", res.Lesson.Name())
+				if err := printExplanations(w, res.Lesson); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	for _, res := range results {
+		if !res.Passed() {
+			return errFailed
+		}
+	}
+	return nil
+}
+
+// selectLessons discovers the lessons of the enclosing module and
+// keeps the days named in args.
+func selectLessons(args []string) ([]lesson.Lesson, error) {
+	root, err := findRoot()
+	if err != nil {
+		return nil, err
+	}
+	days, err := parseDays(args)
+	if err != nil {
+		return nil, err
+	}
+	lessons, err := lesson.Discover(root)
+	if err != nil {
+		return nil, err
+	}
+	return lesson.Select(lessons, days)
+}
+
+func printSummary(w io.Writer, results []runner.Result) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "LESSON\tSTATUS\tEXIT\tTIME")
+	for _, res := range results {
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\n", res.Lesson.Name(), res.Status, res.ExitCode, res.Duration.Round(time.Millisecond))
+	}
+	tw.Flush()
+
+	for _, res := range results {
+		fmt.Fprintln(w)
+		printResult(w, res)
+	}
+}
+
+// printResult prints the output of a single lesson run.
+func printResult(w io.Writer, res runner.Result) {
+	fmt.Fprintf(w, "== %s (%s)\n", res.Lesson.Name(), res.Status)
+	switch res.Status {
+	case runner.StatusBuildFailed, runner.StatusExpectedFail, runner.StatusUnexpectedPass:
+		writeIndented(w, res.BuildOutput)
+	default:
+		writeIndented(w, res.Stdout)
+		writeIndented(w, res.Stderr)
+	}
+	for _, e := range res.Expected {
+		fmt.Fprintf(w, "  expected error confirmed: %s\n", e)
+	}
+}
+
+func writeIndented(w io.Writer, s string) {
+	s = strings.TrimRight(s, "\n")
+	if s == "" {
+		return
+	}
+	for _, line := range strings.Split(s, "\n") {
+		fmt.Fprintln(w, "  "+line)
+	}
+}
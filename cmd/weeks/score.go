@@ -0,0 +1,101 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"example/Hello/internal/concepts"
+	"example/Hello/internal/config"
+	"example/Hello/internal/lesson"
+	"example/Hello/internal/substance"
+)
+
+func init() {
+	commands = append(commands, &command{
+		name:    "score",
+		args:    "[day...]",
+		summary: "score the substance of lessons and flag thin days",
+		run:     runScore,
+	})
+}
+
+func runScore(fs *flag.FlagSet) error {
+	root, err := findRoot()
+	if err != nil {
+		return err
+	}
+	cfg, err := config.Load(root)
+	if err != nil {
+		return err
+	}
+	all, err := lesson.Discover(root)
+	if err != nil {
+		return err
+	}
+	scores, err := substanceScores(all)
+	if err != nil {
+		return err
+	}
+	days, err := parseDays(fs.Args())
+	if err != nil {
+		return err
+	}
+	want := make(map[int]bool)
+	for _, d := range days {
+		want[d] = true
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "LESSON\tSCORE\tNODES\tCONCEPTS\tCOMMENTS\tNOVEL\tNOTE")
+	for _, s := range scores {
+		if len(want) > 0 && !want[s.Day] {
+			continue
+		}
+		thin := ""
+		if s.Value < cfg.Score.Min {
+			thin = "thin"
+		}
+		f := s.Factors
+		fmt.Fprintf(tw, "Day %d\t%.0f\t%d\t%d\t%.2f\t%d\t%s\n", s.Day, s.Value, f.Nodes, f.Concepts, f.CommentRatio, f.Novel, thin)
+	}
+	tw.Flush()
+	fmt.Printf("\nthin days score below %.0f (score.min in %s)\n", cfg.Score.Min, config.FileName)
+	return nil
+}
+
+// substanceScores scores lessons in order; novelty is measured against
+// the lessons before each one.
+func substanceScores(lessons []lesson.Lesson) ([]substance.Score, error) {
+	seen := make(map[string]bool)
+	var scores []substance.Score
+	for _, l := range lessons {
+		p, err := loadLesson(l)
+		if err != nil {
+			return nil, err
+		}
+		f := substance.Measure(p)
+		used := concepts.Analyze(p).Concepts()
+		f.Concepts = len(used)
+		for _, c := range used {
+			if !seen[c] {
+				seen[c] = true
+				f.Novel++
+			}
+		}
+		scores = append(scores, substance.Compute(l.Day, f))
+	}
+	return scores, nil
+}
+
+// thinDays returns the scores below the configured minimum.
+func thinDays(scores []substance.Score, cfg config.Config) []substance.Score {
+	thin := []substance.Score{}
+	for _, s := range scores {
+		if s.Value < cfg.Score.Min {
+			thin = append(thin, s)
+		}
+	}
+	return thin
+}
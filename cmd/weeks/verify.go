@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"example/Hello/internal/golden"
+	"example/Hello/internal/runner"
+)
+
+var verifyUpdate bool
+
+func init() {
+	commands = append(commands, &command{
+		name:    "verify",
+		args:    "[-update] [day...]",
+		summary: "compare lesson output with the saved golden files",
+		flags: func(fs *flag.FlagSet) {
+			fs.BoolVar(&verifyUpdate, "update", false, "rewrite the golden files with the current output")
+			runFlags(fs)
+		},
+		run: runVerify,
+	})
+}
+
+func runVerify(fs *flag.FlagSet) error {
+	lessons, err := selectLessons(fs.Args())
+	if err != nil {
+		return err
+	}
+	r, err := newRunner()
+	if err != nil {
+		return err
+	}
+	failed := false
+	for _, res := range r.RunAll(context.Background(), lessons) {
+		l := res.Lesson
+		if !res.Passed() {
+			fmt.Printf("FAIL %s: %s\n", l.Name(), res.Status)
+			writeIndented(os.Stdout, res.BuildOutput+res.Stderr)
+			failed = true
+			continue
+		}
+		if res.Status == runner.StatusExpectedFail {
+			fmt.Printf("ok   %s (expected failure)\n", l.Name())
+			continue
+		}
+		if verifyUpdate {
+			if err := golden.Write(l, res.Stdout); err != nil {
+				return err
+			}
+			fmt.Printf("updated %s\n", l.Name())
+			continue
+		}
+		want, ok, err := golden.Read(l)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Printf("FAIL %s: no golden file (run with -update)\n", l.Name())
+			failed = true
+			continue
+		}
+		if diff := golden.Diff(want, res.Stdout); diff != "" {
+			fmt.Printf("FAIL %s: output differs (-want +got):\n", l.Name())
+			writeIndented(os.Stdout, diff)
+			failed = true
+			continue
+		}
+		fmt.Printf("ok   %s\n", l.Name())
+	}
+	if failed {
+		return errFailed
+	}
+	return nil
+}
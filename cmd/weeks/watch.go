@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+var watchDebounce time.Duration
+
+func init() {
+	commands = append(commands, &command{
+		name:    "watch",
+		args:    "[-debounce d] day",
+		summary: "rerun a lesson every time its directory changes",
+		flags: func(fs *flag.FlagSet) {
+			fs.DurationVar(&watchDebounce, "debounce", 200*time.Millisecond, "wait this long after the last change before rebuilding")
+			runFlags(fs)
+		},
+		run: runWatch,
+	})
+}
+
+func runWatch(fs *flag.FlagSet) error {
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errFailed
+	}
+	lessons, err := selectLessons(fs.Args())
+	if err != nil {
+		return err
+	}
+	l := lessons[0]
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	// Watch the directory rather than the file: editors that save by
+	// writing a temporary file and renaming it over the original replace
+	// the watched inode, which would silently end a file watch.
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+	if err := w.Add(l.Dir); err != nil {
+		return err
+	}
+
+	r, err := newRunner()
+	if err != nil {
+		return err
+	}
+	show := func() {
+		res := r.Run(ctx, l)
+		if ctx.Err() != nil {
+			return
+		}
+		fmt.Print("\x1b[H\x1b[2J")
+		fmt.Printf("%s  %s  (watching, Ctrl-C to stop)\n\n", l.Name(), time.Now().Format("15:04:05"))
+		printResult(os.Stdout, res)
+	}
+	show()
+
+	timer := time.NewTimer(watchDebounce)
+	timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev, ok := <-w.Events:
+			if !ok {
+				return nil
+			}
+			if relevantChange(ev) {
+				timer.Reset(watchDebounce)
+			}
+		case err, ok := <-w.Errors:
+			if !ok {
+				return nil
+			}
+			if errors.Is(err, fsnotify.ErrEventOverflow) {
+				timer.Reset(watchDebounce)
+				continue
+			}
+			fmt.Fprintln(os.Stderr, "weeks: watch:", err)
+		case <-timer.C:
+			show()
+		}
+	}
+}
+
+// relevantChange reports whether ev should trigger a rebuild.
+func relevantChange(ev fsnotify.Event) bool {
+	if filepath.Ext(ev.Name) != ".go" {
+		return false
+	}
+	return ev.Has(fsnotify.Write) || ev.Has(fsnotify.Create) || ev.Has(fsnotify.Rename) || ev.Has(fsnotify.Remove)
+}
@@ -1,3 +1,10 @@
 module example/Hello
 
 go 1.25.5
+
+require (
+	github.com/BurntSushi/toml v1.5.0
+	github.com/fsnotify/fsnotify v1.9.0
+	github.com/google/go-cmp v0.7.0
+	golang.org/x/sys v0.13.0
+)
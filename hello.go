@@ -1,7 +1,46 @@
 package main
 
-import "fmt"
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"example/Hello/lessons"
+)
 
 func main() {
-	fmt.Println("Hello, World!")
-}
\ No newline at end of file
+	list := flag.Bool("list", false, "list the registered lessons")
+	day := flag.Int("day", 0, "run the lesson of the given `day`")
+	all := flag.Bool("all", false, "run every lesson")
+	flag.Parse()
+
+	switch {
+	case *list:
+		for _, l := range lessons.All() {
+			fmt.Printf("Day %d\t%s\n", l.Day, l.Name)
+		}
+	case *day != 0:
+		l, ok := lessons.Lookup(*day)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "hello: no lesson for day %d\n", *day)
+			os.Exit(1)
+		}
+		if err := l.Run(os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "hello: day %d: %v\n", l.Day, err)
+			os.Exit(1)
+		}
+	case *all:
+		for i, l := range lessons.All() {
+			if i > 0 {
+				fmt.Println()
+			}
+			fmt.Printf("== Day %d: %s\n", l.Day, l.Name)
+			if err := l.Run(os.Stdout); err != nil {
+				fmt.Fprintf(os.Stderr, "hello: day %d: %v\n", l.Day, err)
+				os.Exit(1)
+			}
+		}
+	default:
+		fmt.Println("Hello, World!")
+	}
+}
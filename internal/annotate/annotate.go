@@ -0,0 +1,201 @@
+// Package annotate shows the types the compiler infers for a lesson's
+// declarations. Short variable declarations and var declarations without
+// a type get the inferred type of each new variable; constants get their
+// value and, if untyped, their kind and default type.
+package annotate
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+
+	"example/Hello/internal/check"
+)
+
+// Annotation describes the declarations that end on a line. If Above is
+// set it goes on a line of its own before Line instead, because the end of
+// the line is taken by a comment or lies inside a multi-line literal.
+type Annotation struct {
+	Line  int
+	Text  string
+	Above bool
+}
+
+// Find returns the annotations of p, ordered by line.
+func Find(p *check.Package) []Annotation {
+	if p.File == nil || p.Info == nil {
+		return nil
+	}
+	qual := types.RelativeTo(p.Pkg)
+	open, commented := lineEnds(p)
+	byLine := make(map[Annotation][]string)
+	add := func(n ast.Node, parts []string) {
+		if len(parts) == 0 {
+			return
+		}
+		at := Annotation{Line: p.Fset.Position(n.End()).Line}
+		if open[at.Line] || commented[at.Line] {
+			// Above the first line of the declaration, moving further
+			// up while that would split a multi-line literal.
+			at = Annotation{Line: p.Fset.Position(n.Pos()).Line, Above: true}
+			for open[at.Line-1] {
+				at.Line--
+			}
+		}
+		byLine[at] = append(byLine[at], strings.Join(parts, ", "))
+	}
+	ast.Inspect(p.File, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if n.Tok != token.DEFINE {
+				return true
+			}
+			var idents []*ast.Ident
+			for _, lhs := range n.Lhs {
+				if id, ok := lhs.(*ast.Ident); ok {
+					idents = append(idents, id)
+				}
+			}
+			add(n, vars(p.Info, idents, qual))
+		case *ast.GenDecl:
+			for _, spec := range n.Specs {
+				vs, ok := spec.(*ast.ValueSpec)
+				if !ok {
+					continue
+				}
+				switch {
+				case n.Tok == token.CONST:
+					add(vs, consts(p.Info, vs.Names, qual))
+				case n.Tok == token.VAR && vs.Type == nil:
+					add(vs, vars(p.Info, vs.Names, qual))
+				}
+			}
+		}
+		return true
+	})
+	var out []Annotation
+	for at, texts := range byLine {
+		at.Text = strings.Join(texts, "; ")
+		out = append(out, at)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Line != out[j].Line {
+			return out[i].Line < out[j].Line
+		}
+		return out[i].Above
+	})
+	return out
+}
+
+// lineEnds reports which lines end inside a literal or comment that
+// continues on the next line, and which lines have a comment.
+func lineEnds(p *check.Package) (open, commented map[int]bool) {
+	open, commented = make(map[int]bool), make(map[int]bool)
+	span := func(n ast.Node) {
+		start, end := p.Fset.Position(n.Pos()).Line, p.Fset.Position(n.End()).Line
+		for l := start; l < end; l++ {
+			open[l] = true
+		}
+	}
+	for _, g := range p.File.Comments {
+		for _, c := range g.List {
+			commented[p.Fset.Position(c.Pos()).Line] = true
+			span(c)
+		}
+	}
+	ast.Inspect(p.File, func(n ast.Node) bool {
+		if lit, ok := n.(*ast.BasicLit); ok {
+			span(lit)
+		}
+		return true
+	})
+	return open, commented
+}
+
+// vars describes the variables newly declared by idents. Runs of
+// variables of the same type share it, as in "x, y int".
+func vars(info *types.Info, idents []*ast.Ident, qual types.Qualifier) []string {
+	var parts, names []string
+	var last types.Type
+	flush := func() {
+		if len(names) > 0 {
+			parts = append(parts, strings.Join(names, ", ")+" "+types.TypeString(last, qual))
+		}
+		names = nil
+	}
+	for _, id := range idents {
+		obj, ok := info.Defs[id].(*types.Var)
+		if !ok || id.Name == "_" || !check.ValidType(obj.Type()) {
+			continue // redeclared, blank or broken
+		}
+		if last != nil && !types.Identical(last, obj.Type()) {
+			flush()
+		}
+		names = append(names, id.Name)
+		last = obj.Type()
+	}
+	flush()
+	return parts
+}
+
+// consts describes the constants named by idents with their values.
+func consts(info *types.Info, idents []*ast.Ident, qual types.Qualifier) []string {
+	var parts []string
+	for _, id := range idents {
+		obj, ok := info.Defs[id].(*types.Const)
+		if !ok || id.Name == "_" || !check.ValidType(obj.Type()) {
+			continue
+		}
+		t := obj.Type()
+		s := fmt.Sprintf("%s %s = %s", id.Name, types.TypeString(t, qual), obj.Val())
+		if isUntyped(t) {
+			s += fmt.Sprintf(" (default %s)", types.TypeString(types.Default(t), qual))
+		}
+		parts = append(parts, s)
+	}
+	return parts
+}
+
+func isUntyped(t types.Type) bool {
+	b, ok := t.(*types.Basic)
+	return ok && b.Info()&types.IsUntyped != 0
+}
+
+// Source returns the source of p with the annotations as comments, at
+// the end of their lines or on lines of their own above them.
+func Source(p *check.Package, annotations []Annotation) []byte {
+	above := make(map[int][]string)
+	after := make(map[int]string)
+	for _, a := range annotations {
+		if a.Above {
+			above[a.Line] = append(above[a.Line], a.Text)
+		} else {
+			after[a.Line] = a.Text
+		}
+	}
+	lines := bytes.SplitAfter(p.Src, []byte("\n"))
+	var buf bytes.Buffer
+	for i, line := range lines {
+		body := bytes.TrimRight(line, "\r\n")
+		eol := line[len(body):]
+		indent := body[:len(body)-len(bytes.TrimLeft(body, " \t"))]
+		for _, text := range above[i+1] {
+			fmt.Fprintf(&buf, "%s// %s\n", indent, text)
+		}
+		text, ok := after[i+1]
+		if !ok {
+			buf.Write(line)
+			continue
+		}
+		fmt.Fprintf(&buf, "%s // %s", body, text)
+		if len(eol) == 0 {
+			eol = []byte("\n")
+		}
+		buf.Write(eol)
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,110 @@
+// Package check parses and type-checks a single lesson file with go/types,
+// collecting every error instead of stopping at the first one.
+package check
+
+import (
+	"bytes"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"go/types"
+	"os"
+	"sort"
+)
+
+// Error is a parse or type error in a lesson.
+type Error struct {
+	Pos token.Position
+	Msg string
+}
+
+// Package is a type-checked lesson.
+type Package struct {
+	Fset   *token.FileSet
+	File   *ast.File
+	Src    []byte
+	Pkg    *types.Package
+	Info   *types.Info
+	Errors []Error
+}
+
+// Load reads and checks the lesson file filename.
+func Load(filename string) (*Package, error) {
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return Source(filename, src), nil
+}
+
+// Source checks src as if it were stored in filename.
+func Source(filename string, src []byte) *Package {
+	p := &Package{Fset: token.NewFileSet(), Src: src}
+	f, err := parser.ParseFile(p.Fset, filename, src, parser.ParseComments|parser.AllErrors)
+	if list, ok := err.(scanner.ErrorList); ok {
+		for _, e := range list {
+			p.Errors = append(p.Errors, Error{Pos: e.Pos, Msg: e.Msg})
+		}
+	}
+	p.File = f
+	if f == nil {
+		return p
+	}
+	p.Info = &types.Info{
+		Types:      make(map[ast.Expr]types.TypeAndValue),
+		Defs:       make(map[*ast.Ident]types.Object),
+		Uses:       make(map[*ast.Ident]types.Object),
+		Implicits:  make(map[ast.Node]types.Object),
+		Scopes:     make(map[ast.Node]*types.Scope),
+		Selections: make(map[*ast.SelectorExpr]*types.Selection),
+	}
+	conf := types.Config{
+		Importer: importer.Default(),
+		Error: func(err error) {
+			if te, ok := err.(types.Error); ok {
+				p.Errors = append(p.Errors, Error{Pos: te.Fset.Position(te.Pos), Msg: te.Msg})
+			}
+		},
+	}
+	p.Pkg, _ = conf.Check(f.Name.Name, p.Fset, []*ast.File{f}, p.Info)
+	sort.SliceStable(p.Errors, func(i, j int) bool {
+		a, b := p.Errors[i].Pos, p.Errors[j].Pos
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return p
+}
+
+// Line returns the text of the given 1-based line of the source.
+func (p *Package) Line(n int) string {
+	lines := bytes.Split(p.Src, []byte("\n"))
+	if n < 1 || n > len(lines) {
+		return ""
+	}
+	return string(lines[n-1])
+}
+
+// Text returns the source text of n.
+func (p *Package) Text(n ast.Node) string {
+	return string(p.Src[p.Fset.Position(n.Pos()).Offset:p.Fset.Position(n.End()).Offset])
+}
+
+// InFunc reports whether pos lies inside a function body of f.
+func InFunc(f *ast.File, pos token.Pos) bool {
+	for _, d := range f.Decls {
+		if fd, ok := d.(*ast.FuncDecl); ok && fd.Body != nil && fd.Body.Pos() <= pos && pos < fd.Body.End() {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidType reports whether t is a type the checker could work out.
+func ValidType(t types.Type) bool {
+	b, ok := t.(*types.Basic)
+	return !ok || b.Kind() != types.Invalid
+}
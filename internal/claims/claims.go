@@ -0,0 +1,192 @@
+// Package claims checks the notes in lessons that say a piece of code
+// does not work, such as
+//
+//	// this doesnt work nameclone3 = "tete"
+//
+// The code is spliced back into the lesson where the comment is and type
+// checked, to see whether it still fails. A comment can also state a
+// claim explicitly:
+//
+//	// weeks:fails x = 5
+//	// weeks:fails-toplevel name := "top"
+//
+// The second form splices the code at package level instead of at the
+// comment.
+package claims
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"regexp"
+	"strings"
+
+	"example/Hello/internal/check"
+)
+
+const (
+	markFails         = "weeks:fails"
+	markFailsTopLevel = "weeks:fails-toplevel"
+)
+
+// phrases mark a comment as a claim that its code does not work.
+var phrases = regexp.MustCompile(`(?i)\b(doesn'?t work|does not work|won'?t work|won'?t compile|doesn'?t compile|not allowed|wrong|invalid|error)\b`)
+
+// Claim is a note that some code does not work.
+type Claim struct {
+	Pos     token.Position // of the comment
+	Comment string
+	Code    string // the code the comment talks about
+	// Holds reports whether Code still fails to compile, with Errors
+	// giving the reasons.
+	Holds  bool
+	Errors []string
+}
+
+// Find returns the claims in p that contain code and checks each one.
+func Find(p *check.Package) []Claim {
+	if p.File == nil {
+		return nil
+	}
+	baseline := make(map[string]bool)
+	for _, e := range p.Errors {
+		baseline[e.Msg] = true
+	}
+	var claims []Claim
+	for _, cg := range p.File.Comments {
+		for _, c := range cg.List {
+			text, ok := strings.CutPrefix(c.Text, "//")
+			if !ok {
+				continue
+			}
+			text = strings.TrimSpace(text)
+			topLevel := !check.InFunc(p.File, c.Pos())
+			var code string
+			switch name, rest, _ := strings.Cut(text, " "); name {
+			case markFails:
+				code = strings.TrimSpace(rest)
+			case markFailsTopLevel:
+				code, topLevel = strings.TrimSpace(rest), true
+			default:
+				if strings.HasPrefix(text, "weeks:") || !phrases.MatchString(text) {
+					continue
+				}
+				code = extract(text)
+			}
+			if code == "" {
+				continue
+			}
+			cl := Claim{Pos: p.Fset.Position(c.Pos()), Comment: text, Code: code}
+			cl.Errors = splice(p, c, code, topLevel, baseline)
+			cl.Holds = len(cl.Errors) > 0
+			claims = append(claims, cl)
+		}
+	}
+	return claims
+}
+
+// extract finds the code in a comment: the longest run of words before
+// or after the claim phrase that parses as Go statements.
+func extract(text string) string {
+	loc := phrases.FindStringIndex(text)
+	for _, part := range []string{text[loc[1]:], text[:loc[0]]} {
+		words := strings.Fields(part)
+		for i := range words {
+			for j := len(words); j > i; j-- {
+				if code := strings.Join(words[i:j], " "); isCode(code) {
+					return code
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// isCode reports whether s parses as statements that do something, so
+// that ordinary words such as "code" are not mistaken for Go.
+func isCode(s string) bool {
+	f, err := parser.ParseFile(token.NewFileSet(), "", "package p; func _() {\n"+s+"\n}", 0)
+	if err != nil {
+		return false
+	}
+	body := f.Decls[0].(*ast.FuncDecl).Body.List
+	if len(body) == 0 {
+		return false
+	}
+	for _, st := range body {
+		switch st := st.(type) {
+		case *ast.ExprStmt:
+			if _, ok := st.X.(*ast.CallExpr); !ok {
+				return false
+			}
+		case *ast.EmptyStmt, *ast.LabeledStmt, *ast.BranchStmt:
+			return false
+		}
+	}
+	return true
+}
+
+// splice inserts code into the lesson and returns the errors that it
+// causes, ignoring those the lesson already had.
+func splice(p *check.Package, c *ast.Comment, code string, topLevel bool, baseline map[string]bool) []string {
+	lines := strings.Split(string(p.Src), "\n")
+	pos := p.Fset.Position(c.Pos())
+	var at int // index of the line that receives the code
+	if topLevel {
+		at = len(lines)
+		lines = append(lines, code)
+	} else {
+		at = pos.Line - 1
+		if strings.TrimSpace(lines[at][:pos.Column-1]) == "" {
+			// The comment has the line to itself: replace it.
+			lines[at] = lines[at][:pos.Column-1] + code
+		} else {
+			at++
+			lines = append(lines[:at], append([]string{code}, lines[at:]...)...)
+		}
+	}
+	q := check.Source(pos.Filename, []byte(strings.Join(lines, "\n")))
+	// A claim is about whether the code compiles, not about whether the
+	// lesson goes on to use what it declares.
+	unused := make(map[string]bool)
+	for _, name := range declared(code) {
+		unused["declared and not used: "+name] = true
+	}
+	var errs []string
+	for _, e := range q.Errors {
+		if unused[e.Msg] {
+			continue
+		}
+		if e.Pos.Line == at+1 || !baseline[e.Msg] {
+			errs = append(errs, e.Msg)
+		}
+	}
+	return errs
+}
+
+// declared returns the names that the statements in code declare.
+func declared(code string) []string {
+	f, err := parser.ParseFile(token.NewFileSet(), "", "package p; func _() {\n"+code+"\n}", 0)
+	if err != nil {
+		return nil
+	}
+	var names []string
+	ast.Inspect(f.Decls[0].(*ast.FuncDecl).Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if n.Tok == token.DEFINE {
+				for _, lhs := range n.Lhs {
+					if id, ok := lhs.(*ast.Ident); ok {
+						names = append(names, id.Name)
+					}
+				}
+			}
+		case *ast.ValueSpec:
+			for _, id := range n.Names {
+				names = append(names, id.Name)
+			}
+		}
+		return true
+	})
+	return names
+}
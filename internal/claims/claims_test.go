@@ -0,0 +1,57 @@
+package claims
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"example/Hello/internal/check"
+)
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{`this doesnt work nameclone3 = "tete"`, `nameclone3 = "tete"`},
+		{`name := "again" won't compile`, `name := "again"`},
+		{"this is wrong: fmt.Println(name)", "fmt.Println(name)"},
+		{"x = 5 does not work outside a function", "x = 5"},
+		{"an error message is printed below", ""},
+		{"this is the wrong code", ""},
+	}
+	for _, tt := range tests {
+		if got := extract(tt.text); got != tt.want {
+			t.Errorf("extract(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestFind(t *testing.T) {
+	p, err := check.Load("testdata/claims.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	type claim struct {
+		Line   int
+		Code   string
+		Holds  bool
+		Errors []string
+	}
+	var got []claim
+	for _, c := range Find(p) {
+		got = append(got, claim{c.Pos.Line, c.Code, c.Holds, c.Errors})
+	}
+	want := []claim{
+		{5, `shortname := "top"`, true, []string{"expected declaration, found shortname"}},
+		{8, `nameclone3 = "tete"`, true, []string{"undefined: nameclone3"}},
+		{12, "x = 5", true, []string{"undefined: x"}},
+		// Not using what the claim declares does not make it fail.
+		{13, "age := 18", false, nil},
+		{14, `name := "again"`, true, []string{"no new variables on left side of :="}},
+		{15, "fmt.Println(name)", false, nil},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("claims mismatch (-want +got):\n%s", diff)
+	}
+}
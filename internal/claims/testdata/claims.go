@@ -0,0 +1,19 @@
+package main
+
+import "fmt"
+
+// weeks:fails-toplevel shortname := "top"
+
+func main() {
+	// this doesnt work nameclone3 = "tete"
+	name := "Vincent"
+	fmt.Println(name)
+
+	// weeks:fails x = 5
+	// weeks:fails age := 18
+	// name := "again" won't compile, there is nothing new on the left
+	fmt.Println(name) // this is wrong: fmt.Println(name)
+
+	// an error message is printed below, which is not a claim
+	fmt.Println("error")
+}
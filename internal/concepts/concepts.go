@@ -0,0 +1,378 @@
+// Package concepts tags a lesson with the Go language features it uses.
+package concepts
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+	"strconv"
+	"strings"
+
+	"example/Hello/internal/check"
+)
+
+// Descriptions documents every concept the analyzer can report.
+var Descriptions = map[string]string{
+	"import":               "import declaration",
+	"import-group":         "parenthesized import block",
+	"func-decl":            "function declaration",
+	"line-comment":         "// comment",
+	"block-comment":        "/* */ comment",
+	"var-decl":             "var declaration",
+	"var-typed":            "var with an explicit type",
+	"var-inferred":         "var with the type inferred from the value",
+	"var-zero-value":       "var without a value",
+	"var-multi":            "several names in one var declaration",
+	"var-mixed-types":      "one var declaration introducing different types",
+	"var-package-level":    "var declared outside any function",
+	"short-var-decl":       ":= short variable declaration",
+	"short-var-multi":      ":= declaring several names",
+	"const-decl":           "const declaration",
+	"const-package-level":  "const declared outside any function",
+	"const-typed":          "const with an explicit type",
+	"iota":                 "iota in a const block",
+	"assignment":           "= assignment to an existing variable",
+	"compound-assignment":  "assignment operators such as +=",
+	"inc-dec":              "++ and -- statements",
+	"arithmetic":           "arithmetic operators on numbers",
+	"string-concatenation": "+ on strings",
+	"comparison":           "comparison operators",
+	"logical":              "&&, || and !",
+	"bitwise":              "bitwise and shift operators",
+	"string-literal":       "string literal",
+	"int-literal":          "integer literal",
+	"float-literal":        "floating-point literal",
+	"rune-literal":         "rune literal",
+	"if":                   "if statement",
+	"for":                  "for loop",
+	"range":                "for range loop",
+	"switch":               "switch statement",
+	"func-literal":         "anonymous function",
+	"return":               "return statement",
+	"multiple-arguments":   "call with several arguments",
+}
+
+// Use is one occurrence of a concept.
+type Use struct {
+	Concept string
+	Pos     token.Position
+}
+
+// Inventory lists the concepts and standard library identifiers used by
+// a lesson, in source order.
+type Inventory struct {
+	Uses  []Use
+	Std   []Use // Concept holds a qualified identifier such as "fmt.Println"
+	Forms []Use // Concept holds a declaration form such as "x := v (function)"
+}
+
+// Concepts returns the distinct concepts of inv, sorted.
+func (inv Inventory) Concepts() []string {
+	return distinct(inv.Uses)
+}
+
+// StdIdents returns the distinct standard library identifiers, sorted.
+func (inv Inventory) StdIdents() []string {
+	return distinct(inv.Std)
+}
+
+// DeclForms returns the distinct declaration forms, sorted.
+func (inv Inventory) DeclForms() []string {
+	return distinct(inv.Forms)
+}
+
+// First returns the first use of concept.
+func (inv Inventory) First(concept string) (token.Position, bool) {
+	for _, u := range inv.Uses {
+		if u.Concept == concept {
+			return u.Pos, true
+		}
+	}
+	return token.Position{}, false
+}
+
+func distinct(uses []Use) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, u := range uses {
+		if !seen[u.Concept] {
+			seen[u.Concept] = true
+			out = append(out, u.Concept)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Analyze builds the inventory of a checked lesson. Type information is
+// used where available, so lessons with type errors are still tagged.
+func Analyze(p *check.Package) Inventory {
+	a := &analyzer{p: p}
+	if p.File == nil {
+		return Inventory{}
+	}
+	for _, cg := range p.File.Comments {
+		for _, c := range cg.List {
+			switch {
+			case strings.HasPrefix(c.Text, "// weeks:"):
+				// Directives for the weeks tool, not part of the lesson.
+			case strings.HasPrefix(c.Text, "/*"):
+				a.add("block-comment", c)
+			default:
+				a.add("line-comment", c)
+			}
+		}
+	}
+	for _, d := range p.File.Decls {
+		switch d := d.(type) {
+		case *ast.GenDecl:
+			a.genDecl(d, true)
+		case *ast.FuncDecl:
+			a.add("func-decl", d)
+			if d.Body != nil {
+				a.inspect(d.Body)
+			}
+		}
+	}
+	sort.SliceStable(a.inv.Uses, func(i, j int) bool { return a.inv.Uses[i].Pos.Offset < a.inv.Uses[j].Pos.Offset })
+	return a.inv
+}
+
+type analyzer struct {
+	p   *check.Package
+	inv Inventory
+}
+
+func (a *analyzer) add(concept string, n ast.Node) {
+	a.inv.Uses = append(a.inv.Uses, Use{Concept: concept, Pos: a.p.Fset.Position(n.Pos())})
+}
+
+// form records a declaration form. Names, types and values are
+// replaced by placeholders so that forms of different lessons compare.
+func (a *analyzer) form(f string, n ast.Node, topLevel bool) {
+	scope := "function"
+	if topLevel {
+		scope = "package"
+	}
+	a.inv.Forms = append(a.inv.Forms, Use{Concept: f + " (" + scope + ")", Pos: a.p.Fset.Position(n.Pos())})
+}
+
+// shape returns n placeholders separated by commas: "x, y, z" for
+// names and "v1, v2, v3" for values.
+func shape(n int, placeholder string) string {
+	if n == 1 {
+		return placeholder
+	}
+	parts := make([]string, n)
+	for i := range parts {
+		switch {
+		case placeholder == "x" && i < 3:
+			parts[i] = string(rune('x' + i))
+		default:
+			parts[i] = placeholder + strconv.Itoa(i+1)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func typeShape(t ast.Expr) string {
+	if t == nil {
+		return ""
+	}
+	return " T"
+}
+
+func valueShape(n int, op string) string {
+	if n == 0 {
+		return ""
+	}
+	return op + shape(n, "v")
+}
+
+func (a *analyzer) genDecl(d *ast.GenDecl, topLevel bool) {
+	switch d.Tok {
+	case token.IMPORT:
+		a.add("import", d)
+		if d.Lparen.IsValid() {
+			a.add("import-group", d)
+		}
+		return
+	case token.VAR:
+		a.add("var-decl", d)
+		if topLevel {
+			a.add("var-package-level", d)
+		}
+	case token.CONST:
+		a.add("const-decl", d)
+		if topLevel {
+			a.add("const-package-level", d)
+		}
+	default:
+		return
+	}
+	for _, spec := range d.Specs {
+		vs, ok := spec.(*ast.ValueSpec)
+		if !ok {
+			continue
+		}
+		a.form(d.Tok.String()+" "+shape(len(vs.Names), "x")+typeShape(vs.Type)+valueShape(len(vs.Values), " = "), vs, topLevel)
+		if d.Tok == token.VAR {
+			switch {
+			case vs.Type != nil:
+				a.add("var-typed", vs)
+			case len(vs.Values) > 0:
+				a.add("var-inferred", vs)
+			}
+			if len(vs.Values) == 0 {
+				a.add("var-zero-value", vs)
+			}
+			if len(vs.Names) > 1 {
+				a.add("var-multi", vs)
+				if vs.Type == nil && a.mixedTypes(vs.Names) {
+					a.add("var-mixed-types", vs)
+				}
+			}
+		} else if vs.Type != nil {
+			a.add("const-typed", vs)
+		}
+		for _, v := range vs.Values {
+			a.inspect(v)
+		}
+	}
+}
+
+// mixedTypes reports whether names were given different types.
+func (a *analyzer) mixedTypes(names []*ast.Ident) bool {
+	var first types.Type
+	for _, n := range names {
+		obj := a.defOf(n)
+		if obj == nil {
+			return false
+		}
+		if first == nil {
+			first = obj.Type()
+		} else if !types.Identical(first, obj.Type()) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *analyzer) defOf(id *ast.Ident) types.Object {
+	if a.p.Info == nil {
+		return nil
+	}
+	return a.p.Info.Defs[id]
+}
+
+func (a *analyzer) typeOf(e ast.Expr) types.Type {
+	if a.p.Info == nil {
+		return nil
+	}
+	return a.p.Info.Types[e].Type
+}
+
+func (a *analyzer) inspect(root ast.Node) {
+	ast.Inspect(root, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.DeclStmt:
+			if g, ok := n.Decl.(*ast.GenDecl); ok {
+				a.genDecl(g, false)
+			}
+			return false
+		case *ast.AssignStmt:
+			switch n.Tok {
+			case token.DEFINE:
+				a.form(shape(len(n.Lhs), "x")+valueShape(len(n.Rhs), " := "), n, false)
+				a.add("short-var-decl", n)
+				if len(n.Lhs) > 1 {
+					a.add("short-var-multi", n)
+				}
+			case token.ASSIGN:
+				a.add("assignment", n)
+			default:
+				a.add("compound-assignment", n)
+			}
+		case *ast.IncDecStmt:
+			a.add("inc-dec", n)
+		case *ast.BinaryExpr:
+			a.binary(n)
+		case *ast.UnaryExpr:
+			if n.Op == token.NOT {
+				a.add("logical", n)
+			}
+		case *ast.BasicLit:
+			switch n.Kind {
+			case token.STRING:
+				a.add("string-literal", n)
+			case token.INT:
+				a.add("int-literal", n)
+			case token.FLOAT:
+				a.add("float-literal", n)
+			case token.CHAR:
+				a.add("rune-literal", n)
+			}
+		case *ast.Ident:
+			if n.Name == "iota" {
+				a.add("iota", n)
+			}
+		case *ast.IfStmt:
+			a.add("if", n)
+		case *ast.ForStmt:
+			a.add("for", n)
+		case *ast.RangeStmt:
+			a.add("range", n)
+		case *ast.SwitchStmt, *ast.TypeSwitchStmt:
+			a.add("switch", n)
+		case *ast.FuncLit:
+			a.add("func-literal", n)
+		case *ast.ReturnStmt:
+			a.add("return", n)
+		case *ast.CallExpr:
+			if len(n.Args) > 1 {
+				a.add("multiple-arguments", n)
+			}
+		case *ast.SelectorExpr:
+			a.std(n)
+		}
+		return true
+	})
+}
+
+func (a *analyzer) binary(b *ast.BinaryExpr) {
+	switch b.Op {
+	case token.ADD:
+		if t := a.typeOf(b.X); t != nil {
+			if basic, ok := t.Underlying().(*types.Basic); ok && basic.Info()&types.IsString != 0 {
+				a.add("string-concatenation", b)
+				return
+			}
+		}
+		a.add("arithmetic", b)
+	case token.SUB, token.MUL, token.QUO, token.REM:
+		a.add("arithmetic", b)
+	case token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ:
+		a.add("comparison", b)
+	case token.LAND, token.LOR:
+		a.add("logical", b)
+	case token.AND, token.OR, token.XOR, token.SHL, token.SHR, token.AND_NOT:
+		a.add("bitwise", b)
+	}
+}
+
+// std records a use of an identifier from an imported package.
+func (a *analyzer) std(sel *ast.SelectorExpr) {
+	pkgIdent, ok := sel.X.(*ast.Ident)
+	if !ok || a.p.Info == nil {
+		return
+	}
+	pn, ok := a.p.Info.Uses[pkgIdent].(*types.PkgName)
+	if !ok {
+		return
+	}
+	a.inv.Std = append(a.inv.Std, Use{
+		Concept: pn.Imported().Path() + "." + sel.Sel.Name,
+		Pos:     a.p.Fset.Position(sel.Pos()),
+	})
+}
@@ -0,0 +1,52 @@
+package concepts
+
+import "sort"
+
+// Delta splits two sets of names into new, dropped and reused ones.
+type Delta struct {
+	New     []string `json:"new"`
+	Dropped []string `json:"dropped"`
+	Reused  []string `json:"reused"`
+}
+
+// Changes is what changed from one lesson to the next.
+type Changes struct {
+	Features Delta `json:"features"`
+	Std      Delta `json:"std"`
+	Forms    Delta `json:"forms"`
+}
+
+// Diff compares the inventory of an earlier lesson with a later one.
+func Diff(before, after Inventory) Changes {
+	return Changes{
+		Features: delta(before.Concepts(), after.Concepts()),
+		Std:      delta(before.StdIdents(), after.StdIdents()),
+		Forms:    delta(before.DeclForms(), after.DeclForms()),
+	}
+}
+
+func delta(before, after []string) Delta {
+	d := Delta{New: []string{}, Dropped: []string{}, Reused: []string{}}
+	had := make(map[string]bool, len(before))
+	for _, b := range before {
+		had[b] = true
+	}
+	has := make(map[string]bool, len(after))
+	for _, a := range after {
+		has[a] = true
+		if had[a] {
+			d.Reused = append(d.Reused, a)
+		} else {
+			d.New = append(d.New, a)
+		}
+	}
+	for _, b := range before {
+		if !has[b] {
+			d.Dropped = append(d.Dropped, b)
+		}
+	}
+	sort.Strings(d.New)
+	sort.Strings(d.Dropped)
+	sort.Strings(d.Reused)
+	return d
+}
@@ -0,0 +1,51 @@
+// Package config reads weeks.toml, the settings of the weeks tool at the
+// module root. Every setting is optional.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+)
+
+// FileName is the name of the configuration file.
+const FileName = "weeks.toml"
+
+// Config holds the settings.
+type Config struct {
+	Score Score `toml:"score"`
+}
+
+// Score configures the substance score of lessons.
+type Score struct {
+	// Min is the score below which a lesson counts as a thin day.
+	Min float64 `toml:"min"`
+}
+
+// Default returns the settings used when weeks.toml does not set them.
+func Default() Config {
+	return Config{Score: Score{Min: 25}}
+}
+
+// Load reads the configuration of the module at root.
+func Load(root string) (Config, error) {
+	c := Default()
+	path := filepath.Join(root, FileName)
+	md, err := toml.DecodeFile(path, &c)
+	if errors.Is(err, fs.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return c, err
+	}
+	if keys := md.Undecoded(); len(keys) > 0 {
+		return c, fmt.Errorf("%s: unknown key %q", FileName, keys[0].String())
+	}
+	if c.Score.Min < 0 || c.Score.Min > 100 {
+		return c, fmt.Errorf("%s: score.min must be between 0 and 100", FileName)
+	}
+	return c, nil
+}
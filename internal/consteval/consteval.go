@@ -0,0 +1,292 @@
+// Package consteval evaluates constant expressions the way the compiler
+// does, with arbitrary precision, in the package scope of a lesson. It
+// shows what happens to a value when it is converted to a sized type and
+// how typed arithmetic, which rounds after every operation, differs from
+// untyped arithmetic, which does not.
+package consteval
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"math/big"
+	"regexp"
+	"strings"
+
+	"example/Hello/internal/check"
+)
+
+// Targets are the types a numeric value is converted to.
+var Targets = []string{
+	"int", "int8", "int16", "int32", "int64",
+	"uint", "uint8", "uint16", "uint32", "uint64",
+	"float32", "float64",
+}
+
+// Session evaluates expressions in the scope of a lesson and of the
+// constants defined so far.
+type Session struct {
+	filename string
+	src      []byte
+	names    []string          // defined constants, in order
+	defs     map[string]string // name to expression
+	pkg      *check.Package
+}
+
+// New returns a session for the lesson src. A nil src starts from an
+// empty package.
+func New(filename string, src []byte) (*Session, error) {
+	if src == nil {
+		src = []byte("package main\n")
+	}
+	s := &Session{filename: filename, src: src, defs: make(map[string]string)}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// load checks the lesson with the defined constants appended.
+func (s *Session) load() error {
+	var buf bytes.Buffer
+	buf.Write(s.src)
+	buf.WriteString("\n")
+	lines := bytes.Count(buf.Bytes(), []byte("\n"))
+	for _, n := range s.names {
+		fmt.Fprintf(&buf, "const %s = %s\n", n, s.defs[n])
+	}
+	p := check.Source(s.filename, buf.Bytes())
+	if p.Pkg == nil {
+		return errors.New("cannot load the lesson")
+	}
+	for _, e := range p.Errors {
+		if e.Pos.Line > lines {
+			return errors.New(e.Msg)
+		}
+	}
+	s.pkg = p
+	return nil
+}
+
+// Define adds the constant name = expr, replacing an earlier definition.
+func (s *Session) Define(name, expr string) error {
+	if !token.IsIdentifier(name) {
+		return fmt.Errorf("%q is not a name", name)
+	}
+	if _, err := s.Eval(expr); err != nil {
+		return err
+	}
+	old, redefined := s.defs[name]
+	if !redefined {
+		s.names = append(s.names, name)
+	}
+	s.defs[name] = expr
+	if err := s.load(); err != nil {
+		if redefined {
+			s.defs[name] = old
+		} else {
+			s.names = s.names[:len(s.names)-1]
+			delete(s.defs, name)
+		}
+		return err
+	}
+	return nil
+}
+
+// Constants returns the constants in scope: those of the lesson's
+// package and the defined ones.
+func (s *Session) Constants() []*types.Const {
+	var out []*types.Const
+	scope := s.pkg.Pkg.Scope()
+	for _, n := range scope.Names() {
+		if c, ok := scope.Lookup(n).(*types.Const); ok {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// Result is an evaluated constant expression.
+type Result struct {
+	Expr    string
+	Value   constant.Value
+	Type    types.Type
+	Default types.Type // the type an untyped value gets in a variable
+	// Conversions convert the exact value to each of the Targets.
+	Conversions []Conversion
+	// Typed carry out the arithmetic of an untyped expression in a typed
+	// type, converting each operand first.
+	Typed []Conversion
+}
+
+// Untyped reports whether the value is an untyped constant.
+func (r *Result) Untyped() bool {
+	b, ok := r.Type.(*types.Basic)
+	return ok && b.Info()&types.IsUntyped != 0
+}
+
+// Conversion is the value of an expression in a typed type.
+type Conversion struct {
+	Type  string
+	Expr  string
+	Value constant.Value // nil if Err is set
+	Err   string         // e.g. overflow or truncation
+	Lost  bool           // the value was rounded
+	// Differs is set for typed arithmetic in an integer type on a float
+	// constant, or the reverse, when it gives another value: that is a
+	// different operation rather than a rounding, as 1/3 shows.
+	Differs bool
+}
+
+// Eval evaluates expr.
+func (s *Session) Eval(expr string) (*Result, error) {
+	tv, err := s.eval(expr)
+	if err != nil {
+		return nil, err
+	}
+	if tv.Value == nil {
+		return nil, fmt.Errorf("%s is not constant (type %s)", expr, tv.Type)
+	}
+	r := &Result{Expr: expr, Value: tv.Value, Type: tv.Type, Default: types.Default(tv.Type)}
+	if !isNumeric(tv.Type) {
+		return r, nil
+	}
+	for _, t := range Targets {
+		r.Conversions = append(r.Conversions, s.convert(t, fmt.Sprintf("%s(%s)", t, expr), tv.Value))
+	}
+	if r.Untyped() {
+		if x, err := parser.ParseExpr(expr); err == nil && hasArithmetic(x) {
+			for _, t := range []string{"float32", "float64", "int"} {
+				c := s.convert(t, s.typed(x, t), tv.Value)
+				if c.Value != nil && isInteger(tv.Type) != strings.HasPrefix(t, "int") {
+					c.Differs, c.Lost = c.Lost, false
+				}
+				r.Typed = append(r.Typed, c)
+			}
+		}
+	}
+	return r, nil
+}
+
+func (s *Session) eval(expr string) (types.TypeAndValue, error) {
+	tv, err := types.Eval(s.pkg.Fset, s.pkg.Pkg, token.NoPos, expr)
+	if err != nil {
+		// Drop the position in the expression, e.g. "eval:1:1: ".
+		err = errors.New(evalPos.ReplaceAllString(err.Error(), ""))
+	}
+	return tv, err
+}
+
+var evalPos = regexp.MustCompile(`^eval:\d+:\d+: `)
+
+// convert evaluates expr, a typed form of a value whose exact value is
+// exact.
+func (s *Session) convert(t, expr string, exact constant.Value) Conversion {
+	c := Conversion{Type: t, Expr: expr}
+	tv, err := s.eval(expr)
+	switch {
+	case err == nil:
+	case strings.Contains(err.Error(), "truncated") || strings.HasPrefix(err.Error(), "cannot convert") && constant.ToInt(exact).Kind() != constant.Int:
+		c.Err = "truncated, not an integer"
+		return c
+	case strings.Contains(err.Error(), "overflows") || strings.HasPrefix(err.Error(), "cannot convert"):
+		c.Err = "overflows " + t
+		return c
+	default:
+		c.Err = err.Error()
+		return c
+	}
+	c.Value = tv.Value
+	c.Lost = !equal(tv.Value, exact)
+	return c
+}
+
+// typed rewrites x so that every untyped constant operand is converted
+// to t first, which makes each operation round to t.
+func (s *Session) typed(x ast.Expr, t string) string {
+	var conv func(ast.Expr) ast.Expr
+	conv = func(e ast.Expr) ast.Expr {
+		switch e := e.(type) {
+		case *ast.ParenExpr:
+			return &ast.ParenExpr{X: conv(e.X)}
+		case *ast.UnaryExpr:
+			return &ast.UnaryExpr{Op: e.Op, X: conv(e.X)}
+		case *ast.BinaryExpr:
+			if e.Op == token.SHL || e.Op == token.SHR {
+				return &ast.BinaryExpr{X: conv(e.X), Op: e.Op, Y: e.Y}
+			}
+			return &ast.BinaryExpr{X: conv(e.X), Op: e.Op, Y: conv(e.Y)}
+		}
+		var buf bytes.Buffer
+		format.Node(&buf, token.NewFileSet(), e)
+		if tv, err := s.eval(buf.String()); err == nil && tv.Value != nil && isUntypedType(tv.Type) {
+			return &ast.CallExpr{Fun: ast.NewIdent(t), Args: []ast.Expr{e}}
+		}
+		return e
+	}
+	var buf bytes.Buffer
+	format.Node(&buf, token.NewFileSet(), conv(x))
+	return buf.String()
+}
+
+// hasArithmetic reports whether x contains an operation that typed
+// arithmetic could round.
+func hasArithmetic(x ast.Expr) bool {
+	found := false
+	ast.Inspect(x, func(n ast.Node) bool {
+		if b, ok := n.(*ast.BinaryExpr); ok {
+			switch b.Op {
+			case token.ADD, token.SUB, token.MUL, token.QUO:
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+func isNumeric(t types.Type) bool {
+	b, ok := t.Underlying().(*types.Basic)
+	return ok && b.Info()&types.IsNumeric != 0 && b.Info()&types.IsComplex == 0
+}
+
+func isInteger(t types.Type) bool {
+	b, ok := t.Underlying().(*types.Basic)
+	return ok && b.Info()&types.IsInteger != 0
+}
+
+func isUntypedType(t types.Type) bool {
+	b, ok := t.(*types.Basic)
+	return ok && b.Info()&types.IsUntyped != 0
+}
+
+func equal(x, y constant.Value) bool {
+	if x.Kind() == constant.Unknown || y.Kind() == constant.Unknown {
+		return false
+	}
+	return constant.Compare(constant.ToFloat(x), token.EQL, constant.ToFloat(y))
+}
+
+// Exact returns the exact value of v in decimal, or as a fraction if the
+// decimal does not terminate.
+func Exact(v constant.Value) string {
+	switch x := constant.Val(constant.ToFloat(v)).(type) {
+	case *big.Rat:
+		if x.IsInt() {
+			return x.Num().String()
+		}
+		if n, exact := x.FloatPrec(); exact {
+			return x.FloatString(n)
+		}
+		return x.String()
+	case *big.Float:
+		return x.Text('g', -1)
+	}
+	return v.ExactString()
+}
@@ -0,0 +1,176 @@
+package consteval
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEval(t *testing.T) {
+	tests := []struct {
+		expr    string
+		exact   string
+		typ     string
+		def     string
+		untyped bool
+	}{
+		{"1 << 10", "1024", "untyped int", "int", true},
+		{"1.0 / 3", "1/3", "untyped float", "float64", true},
+		{"0.1 + 0.2", "0.3", "untyped float", "float64", true},
+		{"'a'", "97", "untyped rune", "rune", true},
+		{"int8(5)", "5", "int8", "int8", false},
+		{`"go" + "pher"`, `"gopher"`, "untyped string", "string", true},
+	}
+	s, err := New("eval.go", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		r, err := s.Eval(tt.expr)
+		if err != nil {
+			t.Errorf("Eval(%q): %v", tt.expr, err)
+			continue
+		}
+		got := []any{Exact(r.Value), r.Type.String(), r.Default.String(), r.Untyped()}
+		want := []any{tt.exact, tt.typ, tt.def, tt.untyped}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("Eval(%q) mismatch (-want +got):\n%s", tt.expr, diff)
+		}
+	}
+}
+
+func TestConversions(t *testing.T) {
+	tests := []struct {
+		expr string
+		typ  string
+		err  string
+		lost bool
+	}{
+		{"300", "int8", "overflows int8", false},
+		{"300", "uint16", "", false},
+		{"-1", "uint", "overflows uint", false},
+		{"3.14", "int", "truncated, not an integer", false},
+		{"3.0", "int", "", false},
+		{"0.1", "float32", "", true},
+		{"0.5", "float32", "", false},
+		{"1 << 24 + 1", "float32", "", true},
+		{"1 << 24 + 1", "float64", "", false},
+	}
+	s, err := New("eval.go", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		r, err := s.Eval(tt.expr)
+		if err != nil {
+			t.Errorf("Eval(%q): %v", tt.expr, err)
+			continue
+		}
+		c := find(r.Conversions, tt.typ)
+		if c == nil {
+			t.Errorf("Eval(%q): no conversion to %s", tt.expr, tt.typ)
+			continue
+		}
+		if c.Err != tt.err || c.Lost != tt.lost {
+			t.Errorf("%s(%s): err, lost = %q, %v; want %q, %v", tt.typ, tt.expr, c.Err, c.Lost, tt.err, tt.lost)
+		}
+		if (c.Value == nil) != (tt.err != "") {
+			t.Errorf("%s(%s): value = %v with err %q", tt.typ, tt.expr, c.Value, c.Err)
+		}
+	}
+}
+
+func TestTyped(t *testing.T) {
+	s, err := New("eval.go", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := s.Eval("0.1 + 0.2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := find(r.Typed, "float64")
+	if c == nil {
+		t.Fatal("no typed float64 arithmetic")
+	}
+	if c.Expr != "float64(0.1) + float64(0.2)" || !c.Lost {
+		t.Errorf("typed float64 = %q, lost %v; want float64(0.1) + float64(0.2), lost", c.Expr, c.Lost)
+	}
+	kinds := []struct {
+		expr, typ     string
+		lost, differs bool
+	}{
+		{"1 / 3", "float64", false, true},
+		{"1 / 3", "int", false, false},
+		{"1.0 / 3", "float32", true, false},
+		{"1.0 / 3", "int", false, true},
+		{"1.0 + 2", "int", false, false},
+	}
+	for _, tt := range kinds {
+		r, err := s.Eval(tt.expr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c := find(r.Typed, tt.typ)
+		if c == nil {
+			t.Errorf("Eval(%q): no typed %s arithmetic", tt.expr, tt.typ)
+			continue
+		}
+		if c.Lost != tt.lost || c.Differs != tt.differs {
+			t.Errorf("%s: lost, differs = %v, %v; want %v, %v", c.Expr, c.Lost, c.Differs, tt.lost, tt.differs)
+		}
+	}
+	if r, err := s.Eval("1 + 2"); err != nil || r.Typed == nil {
+		t.Errorf("Eval(1 + 2): typed = %v, %v; want typed arithmetic", r, err)
+	}
+	if r, err := s.Eval("1 << 3"); err != nil || r.Typed != nil {
+		t.Errorf("Eval(1 << 3): typed = %v, %v; want none", r.Typed, err)
+	}
+}
+
+func TestDefine(t *testing.T) {
+	s, err := New("day.go", []byte("package main\n\nconst Big = 1 << 100\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Define("Small", "Big >> 99"); err != nil {
+		t.Fatal(err)
+	}
+	if r, err := s.Eval("Small"); err != nil || Exact(r.Value) != "2" {
+		t.Errorf("Eval(Small) = %v, %v; want 2", r, err)
+	}
+	if err := s.Define("Small", "3"); err != nil {
+		t.Fatal(err)
+	}
+	if r, err := s.Eval("Small"); err != nil || Exact(r.Value) != "3" {
+		t.Errorf("after redefining, Eval(Small) = %v, %v; want 3", r, err)
+	}
+	for _, tt := range []struct{ name, expr string }{
+		{"1x", "1"},
+		{"X", "undefined"},
+		{"Big", "2"},
+	} {
+		if err := s.Define(tt.name, tt.expr); err == nil {
+			t.Errorf("Define(%q, %q) succeeded", tt.name, tt.expr)
+		}
+	}
+	if r, err := s.Eval("Small"); err != nil || Exact(r.Value) != "3" {
+		t.Errorf("after failed definitions, Eval(Small) = %v, %v; want 3", r, err)
+	}
+	var names []string
+	for _, c := range s.Constants() {
+		names = append(names, c.Name())
+	}
+	if diff := cmp.Diff([]string{"Big", "Small"}, names); diff != "" {
+		t.Errorf("constants mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func find(cs []Conversion, typ string) *Conversion {
+	for i := range cs {
+		if cs[i].Type == typ {
+			return &cs[i]
+		}
+	}
+	return nil
+}
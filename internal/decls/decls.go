@@ -0,0 +1,302 @@
+// Package decls explains the declarations of a lesson: where they are
+// declared, whether their types are written or inferred, which zero values
+// they start with, and which other declaration forms would or would not
+// compile in the same place.
+package decls
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"example/Hello/internal/check"
+)
+
+// Forms of declarations.
+const (
+	VarTypedValue = "var with type and value" // var x int = 5
+	VarValue      = "var with inferred type"  // var x = 5
+	VarTyped      = "var without value"       // var x int
+	VarBare       = "var without type or value"
+	Short         = "short variable declaration" // x := 5
+	Const         = "constant"                   // const x = 5
+	TypedConst    = "typed constant"             // const x int = 5
+	Assign        = "assignment"                 // x = 5
+)
+
+// Decl is one declaration.
+type Decl struct {
+	Pos     token.Position
+	Code    string
+	Form    string
+	Grouped bool   // declares several names at once
+	InBlock bool   // part of a parenthesized var or const block
+	Scope   string // "package" or "function"
+	Names   []Name
+	// Alternatives are the other forms, checked in place of the
+	// declaration.
+	Alternatives []Alternative
+}
+
+// Name is a name introduced by a declaration.
+type Name struct {
+	Name     string
+	Type     string
+	Inferred bool   // the type comes from the value
+	Zero     string // the zero value, if no value is given
+}
+
+// Alternative is another way to write a declaration.
+type Alternative struct {
+	Form   string
+	Code   string
+	Legal  bool
+	Errors []string // why it does not compile
+}
+
+// Find explains every var, const and short variable declaration in p.
+func Find(p *check.Package) []Decl {
+	if p.File == nil || p.Info == nil {
+		return nil
+	}
+	baseline := make(map[string]bool)
+	for _, e := range p.Errors {
+		baseline[e.Msg] = true
+	}
+	var out []Decl
+	ast.Inspect(p.File, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if n.Tok != token.DEFINE {
+				return true
+			}
+			var idents []*ast.Ident
+			for _, lhs := range n.Lhs {
+				if id, ok := lhs.(*ast.Ident); ok {
+					idents = append(idents, id)
+				}
+			}
+			d := newDecl(p, n, Short, idents, nil, n.Rhs)
+			out = append(out, d.alternatives(p, n, idents, n.Rhs, baseline))
+		case *ast.GenDecl:
+			if n.Tok != token.VAR && n.Tok != token.CONST {
+				return true
+			}
+			for _, spec := range n.Specs {
+				vs := spec.(*ast.ValueSpec)
+				// A declaration in a block is replaced by other specs,
+				// a single one by whole declarations.
+				var node ast.Node = n
+				if n.Lparen.IsValid() {
+					node = vs
+				}
+				d := newDecl(p, node, form(n.Tok, vs), vs.Names, vs.Type, vs.Values)
+				d.InBlock = n.Lparen.IsValid()
+				out = append(out, d.alternatives(p, node, vs.Names, vs.Values, baseline))
+			}
+		}
+		return true
+	})
+	return out
+}
+
+func form(tok token.Token, vs *ast.ValueSpec) string {
+	switch {
+	case tok == token.CONST && vs.Type != nil:
+		return TypedConst
+	case tok == token.CONST:
+		return Const
+	case vs.Type != nil && len(vs.Values) > 0:
+		return VarTypedValue
+	case vs.Type != nil:
+		return VarTyped
+	case len(vs.Values) > 0:
+		return VarValue
+	}
+	return VarBare
+}
+
+func newDecl(p *check.Package, n ast.Node, form string, idents []*ast.Ident, typ ast.Expr, values []ast.Expr) Decl {
+	d := Decl{
+		Pos:     p.Fset.Position(n.Pos()),
+		Code:    p.Text(n),
+		Form:    form,
+		Grouped: len(idents) > 1,
+		Scope:   "package",
+	}
+	if check.InFunc(p.File, n.Pos()) {
+		d.Scope = "function"
+	}
+	qual := types.RelativeTo(p.Pkg)
+	for _, id := range idents {
+		obj := p.Info.Defs[id]
+		if obj == nil {
+			obj = p.Info.Uses[id] // redeclared by :=
+		}
+		nm := Name{Name: id.Name, Inferred: typ == nil}
+		if obj != nil {
+			nm.Type = types.TypeString(obj.Type(), qual)
+			if len(values) == 0 && form != Const && form != TypedConst {
+				nm.Zero = zero(obj.Type(), qual)
+			}
+		}
+		d.Names = append(d.Names, nm)
+	}
+	return d
+}
+
+// alternatives fills in d.Alternatives by checking each other form of
+// the declaration in place of node.
+func (d Decl) alternatives(p *check.Package, node ast.Node, idents []*ast.Ident, values []ast.Expr, baseline map[string]bool) Decl {
+	isConst := d.Form == Const || d.Form == TypedConst
+	if len(idents) == 0 || len(values) == 0 && isConst {
+		// Nothing is declared, as in a.b := 1, or the constant repeats
+		// the expressions of the one before.
+		return d
+	}
+	var names []string
+	for _, id := range idents {
+		names = append(names, id.Name)
+	}
+	lhs := strings.Join(names, ", ")
+	// The type to write in typed forms: an untyped constant stands for
+	// its default type.
+	var typ string
+	for i, id := range idents {
+		obj := p.Info.Defs[id]
+		if obj == nil {
+			obj = p.Info.Uses[id]
+		}
+		t := ""
+		if obj != nil && check.ValidType(obj.Type()) {
+			t = types.TypeString(types.Default(obj.Type()), types.RelativeTo(p.Pkg))
+		}
+		if i == 0 {
+			typ = t
+		} else if t != typ {
+			typ = "" // no single type fits all names
+		}
+	}
+	var vals []string
+	for _, v := range values {
+		vals = append(vals, p.Text(v))
+	}
+	rhs := strings.Join(vals, ", ")
+
+	type candidate struct{ form, code string }
+	var cands []candidate
+	add := func(form, code string) {
+		if form != d.Form && strings.Join(strings.Fields(code), " ") != strings.Join(strings.Fields(d.Code), " ") {
+			cands = append(cands, candidate{form, code})
+		}
+	}
+	if d.InBlock {
+		// Inside var ( ... ) or const ( ... ) only specs of the same
+		// kind may appear.
+		typedForm, valueForm := VarTypedValue, VarValue
+		if isConst {
+			typedForm, valueForm = TypedConst, Const
+		}
+		if typ != "" && rhs != "" {
+			add(typedForm, fmt.Sprintf("%s %s = %s", lhs, typ, rhs))
+		}
+		if rhs != "" {
+			add(valueForm, fmt.Sprintf("%s = %s", lhs, rhs))
+		}
+		if typ != "" && !isConst {
+			add(VarTyped, fmt.Sprintf("%s %s", lhs, typ))
+		}
+		if rhs != "" {
+			add(Short, fmt.Sprintf("%s := %s", lhs, rhs))
+		}
+	} else {
+		if typ != "" && rhs != "" {
+			add(VarTypedValue, fmt.Sprintf("var %s %s = %s", lhs, typ, rhs))
+		}
+		if rhs != "" {
+			add(VarValue, fmt.Sprintf("var %s = %s", lhs, rhs))
+		}
+		if typ != "" {
+			add(VarTyped, fmt.Sprintf("var %s %s", lhs, typ))
+		}
+		add(VarBare, "var "+lhs)
+		if rhs != "" {
+			add(Short, fmt.Sprintf("%s := %s", lhs, rhs))
+			add(Const, fmt.Sprintf("const %s = %s", lhs, rhs))
+			add(Assign, fmt.Sprintf("%s = %s", lhs, rhs))
+		}
+	}
+	for _, c := range cands {
+		errs := splice(p, node, c.code, baseline)
+		d.Alternatives = append(d.Alternatives, Alternative{Form: c.form, Code: c.code, Legal: len(errs) == 0, Errors: errs})
+	}
+	return d
+}
+
+// splice replaces node with code and returns the errors this causes,
+// ignoring those the lesson already had.
+func splice(p *check.Package, node ast.Node, code string, baseline map[string]bool) []string {
+	start := p.Fset.Position(node.Pos()).Offset
+	end := p.Fset.Position(node.End()).Offset
+	src := string(p.Src[:start]) + code + string(p.Src[end:])
+	name := p.Fset.Position(node.Pos()).Filename
+	// A syntax error hides everything after it, so whatever the type
+	// checker says about the rest of the file is noise.
+	if _, err := parser.ParseFile(token.NewFileSet(), name, src, 0); err != nil {
+		if list, ok := err.(scanner.ErrorList); ok && len(list) > 0 {
+			return []string{list[0].Msg}
+		}
+		return []string{err.Error()}
+	}
+	q := check.Source(name, []byte(src))
+	var errs []string
+	seen := make(map[string]bool)
+	for _, e := range q.Errors {
+		if !baseline[e.Msg] && !seen[e.Msg] {
+			errs = append(errs, e.Msg)
+			seen[e.Msg] = true
+		}
+	}
+	return errs
+}
+
+// zero returns the zero value of t as Go source.
+func zero(t types.Type, qual types.Qualifier) string {
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsBoolean != 0:
+			return "false"
+		case u.Info()&types.IsString != 0:
+			return `""`
+		case u.Info()&types.IsNumeric != 0:
+			return "0"
+		case u.Kind() == types.UnsafePointer:
+			return "nil"
+		}
+	case *types.Struct, *types.Array:
+		return types.TypeString(t, qual) + "{}"
+	case *types.Pointer, *types.Slice, *types.Map, *types.Chan, *types.Signature, *types.Interface:
+		return "nil"
+	}
+	return ""
+}
+
+// Snippet checks a pasted piece of code. Without a package clause it is
+// taken as package-level declarations if it parses as such, and as the
+// body of func main otherwise. Positions refer to the snippet's lines.
+func Snippet(src string) *check.Package {
+	const name = "snippet.go"
+	if f, err := parser.ParseFile(token.NewFileSet(), name, src, parser.PackageClauseOnly); err == nil && f.Name != nil {
+		return check.Source(name, []byte(src))
+	}
+	file := "package main\n//line " + name + ":1\n" + src + "\n"
+	if _, err := parser.ParseFile(token.NewFileSet(), name, file, 0); err == nil {
+		return check.Source(name, []byte(file))
+	}
+	return check.Source(name, []byte("package main\n\nfunc main() {\n//line "+name+":1\n"+src+"\n}\n"))
+}
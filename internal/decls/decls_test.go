@@ -0,0 +1,138 @@
+package decls
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFind(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		form    string
+		scope   string
+		names   []Name
+		legal   []string
+		illegal []string
+	}{
+		{
+			name:    "short declaration",
+			src:     `name := "Vincent"`,
+			form:    Short,
+			scope:   "function",
+			names:   []Name{{Name: "name", Type: "string", Inferred: true}},
+			legal:   []string{`var name string = "Vincent"`, `var name = "Vincent"`, "var name string", `const name = "Vincent"`},
+			illegal: []string{"var name", `name = "Vincent"`},
+		},
+		{
+			name:    "typed var",
+			src:     `var nameclone string = "Tnecniv"`,
+			form:    VarTypedValue,
+			scope:   "package",
+			names:   []Name{{Name: "nameclone", Type: "string"}},
+			legal:   []string{`var nameclone = "Tnecniv"`, "var nameclone string", `const nameclone = "Tnecniv"`},
+			illegal: []string{"var nameclone", `nameclone := "Tnecniv"`, `nameclone = "Tnecniv"`},
+		},
+		{
+			name:    "var without value",
+			src:     "var n int",
+			form:    VarTyped,
+			scope:   "package",
+			names:   []Name{{Name: "n", Type: "int", Zero: "0"}},
+			illegal: []string{"var n"},
+		},
+		{
+			name:    "grouped",
+			src:     `var s, ints = "string", 15`,
+			form:    VarValue,
+			scope:   "package",
+			names:   []Name{{Name: "s", Type: "string", Inferred: true}, {Name: "ints", Type: "int", Inferred: true}},
+			legal:   []string{`const s, ints = "string", 15`},
+			illegal: []string{"var s, ints", `s, ints := "string", 15`, `s, ints = "string", 15`},
+		},
+		{
+			name:    "untyped constant",
+			src:     "const PI = 3.14",
+			form:    Const,
+			scope:   "package",
+			names:   []Name{{Name: "PI", Type: "untyped float", Inferred: true}},
+			legal:   []string{"var PI float64 = 3.14", "var PI = 3.14", "var PI float64"},
+			illegal: []string{"var PI", "PI := 3.14", "PI = 3.14"},
+		},
+		{
+			name:    "const block",
+			src:     "const (\n\tA = iota\n)",
+			form:    Const,
+			scope:   "package",
+			names:   []Name{{Name: "A", Type: "untyped int", Inferred: true}},
+			legal:   []string{"A int = iota"},
+			illegal: []string{"A := iota"},
+		},
+		{
+			name:  "nothing declared",
+			src:   "a.b := 1",
+			form:  Short,
+			scope: "function",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ds := Find(Snippet(tt.src))
+			if len(ds) == 0 {
+				t.Fatal("no declarations found")
+			}
+			d := ds[0]
+			if d.Form != tt.form || d.Scope != tt.scope {
+				t.Errorf("form, scope = %q, %q; want %q, %q", d.Form, d.Scope, tt.form, tt.scope)
+			}
+			if diff := cmp.Diff(tt.names, d.Names); diff != "" {
+				t.Errorf("names mismatch (-want +got):\n%s", diff)
+			}
+			var legal, illegal []string
+			for _, a := range d.Alternatives {
+				if strings.Contains(a.Code, "untyped") {
+					t.Errorf("alternative %q spells an untyped type", a.Code)
+				}
+				if a.Code == d.Code {
+					t.Errorf("alternative %q repeats the declaration", a.Code)
+				}
+				if a.Legal {
+					legal = append(legal, a.Code)
+				} else {
+					illegal = append(illegal, a.Code)
+				}
+			}
+			if diff := cmp.Diff(tt.legal, legal); diff != "" {
+				t.Errorf("legal alternatives mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.illegal, illegal); diff != "" {
+				t.Errorf("illegal alternatives mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSnippetPositions(t *testing.T) {
+	ds := Find(Snippet("x := 1\ny := 2"))
+	if len(ds) != 2 {
+		t.Fatalf("found %d declarations, want 2", len(ds))
+	}
+	if ds[1].Pos.Filename != "snippet.go" || ds[1].Pos.Line != 2 {
+		t.Errorf("second declaration at %s, want snippet.go:2", ds[1].Pos)
+	}
+}
+
+func TestSyntaxErrorsOnly(t *testing.T) {
+	ds := Find(Snippet("const (\n\tA = iota\n\tB\n)"))
+	for _, a := range ds[0].Alternatives {
+		if a.Code == "A := iota" {
+			if diff := cmp.Diff([]string{"expected ';', found ':='"}, a.Errors); diff != "" {
+				t.Errorf("errors mismatch (-want +got):\n%s", diff)
+			}
+			return
+		}
+	}
+	t.Error("no short declaration alternative")
+}
@@ -0,0 +1,40 @@
+// Package diag parses compiler diagnostics printed by the go tool.
+package diag
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Diagnostic is a single compiler message.
+type Diagnostic struct {
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Col     int    `json:"col"`
+	Message string `json:"message"`
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s", d.File, d.Line, d.Col, d.Message)
+}
+
+var linePattern = regexp.MustCompile(`^([^:\s][^:]*\.go):(\d+):(?:(\d+):)? (.*)$`)
+
+// Parse extracts the diagnostics from the output of go build.
+// Lines that are not diagnostics, such as package headers, are skipped.
+func Parse(out string) []Diagnostic {
+	var diags []Diagnostic
+	for _, line := range strings.Split(out, "\n") {
+		m := linePattern.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		d := Diagnostic{File: strings.TrimPrefix(m[1], "./"), Message: m[4]}
+		d.Line, _ = strconv.Atoi(m[2])
+		d.Col, _ = strconv.Atoi(m[3])
+		diags = append(diags, d)
+	}
+	return diags
+}
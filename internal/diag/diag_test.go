@@ -0,0 +1,56 @@
+package diag
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		out  string
+		want []Diagnostic
+	}{
+		{"empty", "", nil},
+		{
+			"header and column",
+			"# command-line-arguments\n./day2.go:7:2: undefined: x\n",
+			[]Diagnostic{{File: "day2.go", Line: 7, Col: 2, Message: "undefined: x"}},
+		},
+		{
+			"no column",
+			"day4.go:3: syntax error: unexpected newline",
+			[]Diagnostic{{File: "day4.go", Line: 3, Message: "syntax error: unexpected newline"}},
+		},
+		{
+			"path with space",
+			"Day 4/day4.go:12:5: declared and not used: y",
+			[]Diagnostic{{File: "Day 4/day4.go", Line: 12, Col: 5, Message: "declared and not used: y"}},
+		},
+		{
+			"several",
+			"./a.go:1:1: first\nnot a diagnostic\n./a.go:2:3: second",
+			[]Diagnostic{
+				{File: "a.go", Line: 1, Col: 1, Message: "first"},
+				{File: "a.go", Line: 2, Col: 3, Message: "second"},
+			},
+		},
+		{"message mentioning a file", "unexpected error: file.go:1:2: oops", nil},
+		{"go command error", "go: finding module for package example.com/nope", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, Parse(tt.out)); diff != "" {
+				t.Errorf("Parse(%q) mismatch (-want +got):\n%s", tt.out, diff)
+			}
+		})
+	}
+}
+
+func TestString(t *testing.T) {
+	d := Diagnostic{File: "day2.go", Line: 7, Col: 2, Message: "undefined: x"}
+	if got, want := d.String(), "day2.go:7:2: undefined: x"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,162 @@
+// Package expect reads the "expected to fail" markers of a lesson.
+//
+// A whole lesson is expected not to compile when it contains
+//
+//	// weeks:expect-error undefined: x
+//
+// A region of a lesson is marked with
+//
+//	// weeks:expect-error-begin undefined: x
+//	x = 5
+//	// weeks:expect-error-end
+//
+// in which case the rest of the lesson must still compile and run.
+package expect
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+
+	"example/Hello/internal/diag"
+)
+
+const (
+	markError = "weeks:expect-error"
+	markBegin = "weeks:expect-error-begin"
+	markEnd   = "weeks:expect-error-end"
+)
+
+// Region is a range of lines expected to fail with Message.
+type Region struct {
+	Start, End int // lines of the begin and end markers
+	Message    string
+}
+
+// Spec lists the expected compiler errors of a lesson.
+type Spec struct {
+	Errors  []string // errors expected anywhere in the lesson
+	Regions []Region
+}
+
+// Empty reports whether s expects nothing.
+func (s Spec) Empty() bool {
+	return len(s.Errors) == 0 && len(s.Regions) == 0
+}
+
+// Parse reads the markers from a lesson's source.
+func Parse(src []byte) (Spec, error) {
+	var spec Spec
+	var open *Region
+	sc := bufio.NewScanner(bytes.NewReader(src))
+	for n := 1; sc.Scan(); n++ {
+		text, ok := strings.CutPrefix(strings.TrimSpace(sc.Text()), "//")
+		if !ok {
+			continue
+		}
+		text = strings.TrimSpace(text)
+		name, arg, _ := strings.Cut(text, " ")
+		arg = strings.TrimSpace(arg)
+		switch name {
+		case markError:
+			if arg == "" {
+				return Spec{}, fmt.Errorf("line %d: %s needs an error message", n, markError)
+			}
+			spec.Errors = append(spec.Errors, arg)
+		case markBegin:
+			if open != nil {
+				return Spec{}, fmt.Errorf("line %d: region started on line %d is not closed", n, open.Start)
+			}
+			if arg == "" {
+				return Spec{}, fmt.Errorf("line %d: %s needs an error message", n, markBegin)
+			}
+			open = &Region{Start: n, Message: arg}
+		case markEnd:
+			if open == nil {
+				return Spec{}, fmt.Errorf("line %d: %s without %s", n, markEnd, markBegin)
+			}
+			open.End = n
+			spec.Regions = append(spec.Regions, *open)
+			open = nil
+		}
+	}
+	if open != nil {
+		return Spec{}, fmt.Errorf("line %d: region is not closed", open.Start)
+	}
+	return spec, sc.Err()
+}
+
+// Check compares the diagnostics of a failed build with s. It returns
+// the expected messages that were confirmed and a list of problems:
+// unexpected errors and expected errors that were not reported.
+func (s Spec) Check(diags []diag.Diagnostic) (confirmed []string, problems []string) {
+	regionSeen := make([]bool, len(s.Regions))
+	errorSeen := make([]bool, len(s.Errors))
+	for _, d := range diags {
+		if i := s.region(d); i >= 0 {
+			regionSeen[i] = true
+			continue
+		}
+		if i := s.error(d); i >= 0 {
+			errorSeen[i] = true
+			continue
+		}
+		problems = append(problems, "unexpected error: "+d.String())
+	}
+	for i, e := range s.Errors {
+		if errorSeen[i] {
+			confirmed = append(confirmed, e)
+		} else {
+			problems = append(problems, "expected error not reported: "+e)
+		}
+	}
+	for i, r := range s.Regions {
+		if regionSeen[i] {
+			confirmed = append(confirmed, r.Message)
+		} else {
+			problems = append(problems, fmt.Sprintf("expected error not reported in lines %d-%d: %s", r.Start, r.End, r.Message))
+		}
+	}
+	return confirmed, problems
+}
+
+// Expects reports whether d is one of the errors s expects.
+func (s Spec) Expects(d diag.Diagnostic) bool {
+	return s.region(d) >= 0 || s.error(d) >= 0
+}
+
+// region returns the index of the region expecting d, or -1.
+func (s Spec) region(d diag.Diagnostic) int {
+	for i, r := range s.Regions {
+		if d.Line > r.Start && d.Line < r.End && d.Message == r.Message {
+			return i
+		}
+	}
+	return -1
+}
+
+// error returns the index of the lesson-wide error matching d, or -1.
+func (s Spec) error(d diag.Diagnostic) int {
+	for i, e := range s.Errors {
+		if d.Message == e {
+			return i
+		}
+	}
+	return -1
+}
+
+// Strip blanks the lines inside the regions of s, keeping line numbers intact.
+func (s Spec) Strip(src []byte) []byte {
+	lines := bytes.SplitAfter(src, []byte("\n"))
+	for _, r := range s.Regions {
+		for n := r.Start + 1; n < r.End && n <= len(lines); n++ {
+			if bytes.HasSuffix(lines[n-1], []byte("\n")) {
+				lines[n-1] = []byte("\n")
+			} else {
+				lines[n-1] = nil
+			}
+		}
+	}
+	return bytes.Join(lines, nil)
+}
@@ -0,0 +1,111 @@
+package expect
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"example/Hello/internal/diag"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		want    Spec
+		wantErr string
+	}{
+		{"none", "package main\n// a comment\n", Spec{}, ""},
+		{
+			"whole lesson",
+			"package main\n// weeks:expect-error undefined: x\n",
+			Spec{Errors: []string{"undefined: x"}}, "",
+		},
+		{
+			"region",
+			"package main\n\t// weeks:expect-error-begin undefined: x\n\tx = 5\n\t// weeks:expect-error-end\n",
+			Spec{Regions: []Region{{Start: 2, End: 4, Message: "undefined: x"}}}, "",
+		},
+		{"missing message", "// weeks:expect-error\n", Spec{}, "line 1: weeks:expect-error needs an error message"},
+		{"missing region message", "// weeks:expect-error-begin\n", Spec{}, "line 1: weeks:expect-error-begin needs an error message"},
+		{"unclosed", "// weeks:expect-error-begin m\n", Spec{}, "line 1: region is not closed"},
+		{"nested", "// weeks:expect-error-begin a\n// weeks:expect-error-begin b\n", Spec{}, "line 2: region started on line 1 is not closed"},
+		{"end without begin", "// weeks:expect-error-end\n", Spec{}, "line 1: weeks:expect-error-end without weeks:expect-error-begin"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse([]byte(tt.src))
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("Parse error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Parse mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	spec := Spec{
+		Errors:  []string{"declared and not used: y"},
+		Regions: []Region{{Start: 2, End: 4, Message: "undefined: x"}},
+	}
+	tests := []struct {
+		name          string
+		diags         []diag.Diagnostic
+		wantConfirmed []string
+		wantProblems  []string
+	}{
+		{
+			"all confirmed",
+			[]diag.Diagnostic{{Line: 3, Message: "undefined: x"}, {Line: 9, Message: "declared and not used: y"}},
+			[]string{"declared and not used: y", "undefined: x"}, nil,
+		},
+		{
+			"outside region",
+			[]diag.Diagnostic{{File: "a.go", Line: 5, Col: 1, Message: "undefined: x"}, {Line: 9, Message: "declared and not used: y"}},
+			[]string{"declared and not used: y"},
+			[]string{"unexpected error: a.go:5:1: undefined: x", "expected error not reported in lines 2-4: undefined: x"},
+		},
+		{
+			"nothing reported",
+			nil,
+			nil,
+			[]string{"expected error not reported: declared and not used: y", "expected error not reported in lines 2-4: undefined: x"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			confirmed, problems := spec.Check(tt.diags)
+			if diff := cmp.Diff(tt.wantConfirmed, confirmed); diff != "" {
+				t.Errorf("confirmed mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantProblems, problems); diff != "" {
+				t.Errorf("problems mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestStrip(t *testing.T) {
+	src := "a\n// weeks:expect-error-begin m\nb\nc\n// weeks:expect-error-end\nd"
+	spec, err := Parse([]byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(spec.Strip([]byte(src)))
+	want := "a\n// weeks:expect-error-begin m\n\n\n// weeks:expect-error-end\nd"
+	if got != want {
+		t.Errorf("Strip = %q, want %q", got, want)
+	}
+	if strings.Count(got, "\n") != strings.Count(src, "\n") {
+		t.Error("Strip changed the number of lines")
+	}
+}
@@ -0,0 +1,493 @@
+// Package explain translates compiler errors in lessons into plain
+// English, with a corrected version of the learner's own code.
+package explain
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+
+	"example/Hello/internal/check"
+)
+
+// Explanation describes one error in a lesson.
+type Explanation struct {
+	Pos     token.Position
+	Message string // the error as reported by the type checker
+	Class   string // e.g. "undefined"; empty if the error is not recognized
+	Summary string // what went wrong, in plain English
+	Fix     string // corrected snippet, if one could be built
+	Import  string // package the fix needs that the file does not import
+}
+
+// class recognizes one kind of error and explains it.
+type class struct {
+	name    string
+	explain func(p *check.Package, e check.Error, x *Explanation) bool
+}
+
+var classes = []class{
+	{"undefined", explainUndefined},
+	{"short-decl-outside-func", explainShortDeclOutsideFunc},
+	{"declared-and-not-used", explainUnused},
+	{"imported-and-not-used", explainUnusedImport},
+	{"mismatched-types", explainMismatched},
+}
+
+// maxRechecks bounds how often a lesson is checked again after fixing a
+// syntax error that hides the rest of the file.
+const maxRechecks = 10
+
+// File explains every error in the lesson file filename with content src.
+func File(filename string, src []byte) []Explanation {
+	var rewrites []Explanation
+	fixed := make(map[int]bool) // lines already rewritten in src
+	for range maxRechecks {
+		p := check.Source(filename, src)
+		var out []Explanation
+		var rewrite *Explanation
+		for _, e := range p.Errors {
+			if fixed[e.Pos.Line] {
+				continue
+			}
+			x := explainOne(p, e)
+			out = append(out, x)
+			if x.Class == "short-decl-outside-func" && rewrite == nil {
+				rewrite = &x
+			}
+		}
+		if rewrite == nil {
+			return merge(rewrites, out)
+		}
+		// The parser gives up on the rest of the file after a statement
+		// at package level, so check the file again with the fix applied.
+		rewrites = append(rewrites, *rewrite)
+		fixed[rewrite.Pos.Line] = true
+		src = replaceLine(src, rewrite.Pos.Line, rewrite.Fix)
+	}
+	return rewrites
+}
+
+// merge combines two lists of explanations in source order.
+func merge(a, b []Explanation) []Explanation {
+	out := append(append([]Explanation(nil), a...), b...)
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Pos.Line != out[j].Pos.Line {
+			return out[i].Pos.Line < out[j].Pos.Line
+		}
+		return out[i].Pos.Column < out[j].Pos.Column
+	})
+	return out
+}
+
+func explainOne(p *check.Package, e check.Error) Explanation {
+	x := Explanation{Pos: e.Pos, Message: e.Msg}
+	for _, c := range classes {
+		if c.explain(p, e, &x) {
+			x.Class = c.name
+			break
+		}
+	}
+	return x
+}
+
+func explainUndefined(p *check.Package, e check.Error, x *Explanation) bool {
+	name, ok := strings.CutPrefix(e.Msg, "undefined: ")
+	if !ok || strings.Contains(name, ".") {
+		return false
+	}
+	line := p.Line(e.Pos.Line)
+	indent := indentOf(line)
+	path := pathTo(p.File, p.Fset, e.Pos)
+	if len(path) >= 2 {
+		if as, ok := path[len(path)-2].(*ast.AssignStmt); ok && as.Tok == token.ASSIGN && isLHS(as, path[len(path)-1]) {
+			x.Summary = fmt.Sprintf("%s has never been declared, so there is nothing to assign to. "+
+				"A plain = only changes a variable that already exists. "+
+				"To create %s, declare it with := (inside a function) or with var.", name, name)
+			x.Fix = indent + lhsText(p, as) + " := " + rhsText(p, as) + "\n" +
+				indent + "// or: var " + lhsText(p, as) + " = " + rhsText(p, as)
+			x.Fix += uses(p, e.Pos, x.Fix, indent, as.Lhs)
+			return true
+		}
+	}
+	x.Summary = fmt.Sprintf("%s is used here, but Go does not know any variable, constant or function called %s. "+
+		"Check the spelling, or declare it before this line.", name, name)
+	typ := "int"
+	if t := useType(p, path); t != nil {
+		typ = typeName(p, t)
+		x.Summary += fmt.Sprintf(" It is used as %s here.", article(typ))
+	}
+	x.Fix = indent + "var " + name + " " + typ + " // declare it first, with the value you need\n" + line
+	x.Fix += uses(p, e.Pos, x.Fix, indent, []ast.Expr{ast.NewIdent(name)})
+	return true
+}
+
+// useType returns the type the identifier at the end of path must have
+// in its context, or nil if the context does not tell.
+func useType(p *check.Package, path []ast.Node) types.Type {
+	if p.Info == nil || len(path) < 2 {
+		return nil
+	}
+	id := path[len(path)-1]
+	typeOf := func(e ast.Expr) types.Type {
+		t := p.Info.Types[e].Type
+		if t == nil || t == types.Typ[types.Invalid] {
+			return nil
+		}
+		return types.Default(t)
+	}
+	var t types.Type
+	switch n := path[len(path)-2].(type) {
+	case *ast.BinaryExpr:
+		switch {
+		case n.Op == token.LAND || n.Op == token.LOR:
+			t = types.Typ[types.Bool]
+		case n.Op == token.SHL || n.Op == token.SHR:
+			if n.Y == id {
+				t = types.Typ[types.Uint]
+			}
+		case n.X == id:
+			t = typeOf(n.Y)
+		default:
+			t = typeOf(n.X)
+		}
+	case *ast.UnaryExpr:
+		if n.Op == token.NOT {
+			t = types.Typ[types.Bool]
+		}
+	case *ast.IncDecStmt:
+		t = types.Typ[types.Int]
+	case *ast.AssignStmt:
+		if len(n.Lhs) != len(n.Rhs) {
+			break
+		}
+		for i := range n.Lhs {
+			switch {
+			case n.Rhs[i] == id && n.Tok != token.DEFINE:
+				t = typeOf(n.Lhs[i])
+			case n.Lhs[i] == id:
+				t = typeOf(n.Rhs[i])
+			}
+		}
+	case *ast.ValueSpec:
+		if n.Type != nil {
+			t = typeOf(n.Type)
+		}
+	case *ast.IfStmt, *ast.ForStmt:
+		t = types.Typ[types.Bool]
+	case *ast.IndexExpr:
+		if n.Index == id {
+			t = types.Typ[types.Int]
+		}
+	case *ast.CallExpr:
+		sig, ok := p.Info.Types[n.Fun].Type.(*types.Signature)
+		if !ok {
+			break
+		}
+		for i, a := range n.Args {
+			if a != id {
+				continue
+			}
+			params := sig.Params()
+			switch {
+			case sig.Variadic() && i >= params.Len()-1:
+				t = params.At(params.Len() - 1).Type().(*types.Slice).Elem()
+			case i < params.Len():
+				t = params.At(i).Type()
+			}
+		}
+	case *ast.ReturnStmt:
+		for i := len(path) - 3; i >= 0; i-- {
+			fd, ok := path[i].(*ast.FuncDecl)
+			if !ok {
+				continue
+			}
+			sig, ok := p.Info.Defs[fd.Name].Type().(*types.Signature)
+			if ok && sig.Results().Len() == len(n.Results) {
+				for j, r := range n.Results {
+					if r == id {
+						t = sig.Results().At(j).Type()
+					}
+				}
+			}
+			break
+		}
+	}
+	if t == nil || types.IsInterface(t) {
+		return nil
+	}
+	return t
+}
+
+// uses returns the lines that use the names fix declares, if replacing
+// the line at pos by fix leaves them unused: assigning to a variable with
+// = does not count as using it.
+func uses(p *check.Package, pos token.Position, fix, indent string, names []ast.Expr) string {
+	unused := make(map[string]bool)
+	q := check.Source(pos.Filename, replaceLine(p.Src, pos.Line, fix))
+	for _, e := range q.Errors {
+		if name, ok := strings.CutPrefix(e.Msg, "declared and not used: "); ok {
+			unused[name] = true
+		}
+	}
+	var out string
+	for _, n := range names {
+		if id, ok := n.(*ast.Ident); ok && unused[id.Name] {
+			out += "\n" + indent + useOf(p, id.Name)
+		}
+	}
+	return out
+}
+
+// useOf returns a statement that uses name.
+func useOf(p *check.Package, name string) string {
+	if imports(p.File, "fmt") {
+		return "fmt.Println(" + name + ")"
+	}
+	return "_ = " + name
+}
+
+var shortDeclLine = regexp.MustCompile(`^(\s*)([\pL_][\pL\pN_]*(?:\s*,\s*[\pL_][\pL\pN_]*)*)\s*:=\s*(.+)$`)
+
+func explainShortDeclOutsideFunc(p *check.Package, e check.Error, x *Explanation) bool {
+	if !strings.HasPrefix(e.Msg, "expected declaration") {
+		return false
+	}
+	m := shortDeclLine.FindStringSubmatch(p.Line(e.Pos.Line))
+	if m == nil {
+		return false
+	}
+	x.Summary = "The short declaration := can only be used inside a function. " +
+		"At the top level of a file, every statement must start with a keyword such as var, const, func or type."
+	x.Fix = m[1] + "var " + m[2] + " = " + m[3]
+	return true
+}
+
+func explainUnused(p *check.Package, e check.Error, x *Explanation) bool {
+	name, ok := strings.CutPrefix(e.Msg, "declared and not used: ")
+	if !ok {
+		return false
+	}
+	line := p.Line(e.Pos.Line)
+	x.Summary = fmt.Sprintf("%s is declared but never read. Go refuses to compile variables "+
+		"that are never used, because they are usually a mistake. Use it, or delete the declaration.", name)
+	x.Fix = line + "\n" + indentOf(line) + useOf(p, name)
+	return true
+}
+
+func explainUnusedImport(p *check.Package, e check.Error, x *Explanation) bool {
+	if !strings.HasSuffix(e.Msg, " imported and not used") {
+		return false
+	}
+	path := strings.TrimSuffix(e.Msg, " imported and not used")
+	x.Summary = fmt.Sprintf("The package %s is imported but nothing in the file uses it. "+
+		"Go does not allow unused imports: remove the import or use the package.", path)
+	x.Fix = "// remove this line: " + strings.TrimSpace(p.Line(e.Pos.Line))
+	return true
+}
+
+var mismatched = regexp.MustCompile(`^invalid operation: .* \(mismatched types (.+) and (.+)\)$`)
+
+func explainMismatched(p *check.Package, e check.Error, x *Explanation) bool {
+	m := mismatched.FindStringSubmatch(e.Msg)
+	if m == nil || p.Info == nil {
+		return false
+	}
+	bin := binaryAt(p, e.Pos)
+	if bin == nil {
+		return false
+	}
+	xt, yt := p.Info.Types[bin.X].Type, p.Info.Types[bin.Y].Type
+	if xt == nil || yt == nil {
+		return false
+	}
+	x.Summary = fmt.Sprintf("%s is %s and %s is %s. Go never converts between types on its own, "+
+		"so %s cannot combine them. Convert one side so both have the same type.",
+		p.Text(bin.X), article(m[1]), p.Text(bin.Y), article(m[2]), bin.Op)
+
+	xs, ys := p.Text(bin.X), p.Text(bin.Y)
+	switch {
+	case isString(xt) && !isString(yt):
+		ys = toString(p, x, ys, yt)
+	case isString(yt) && !isString(xt):
+		xs = toString(p, x, xs, xt)
+	case isNumeric(xt) && isNumeric(yt):
+		// Convert toward the type that holds more values, so that the
+		// fix does not silently drop a fraction or the high bits.
+		from, to := yt, xt
+		if wider(yt, xt) {
+			from, to = xt, yt
+			xs = typeName(p, to) + "(" + xs + ")"
+		} else {
+			ys = typeName(p, to) + "(" + ys + ")"
+		}
+		if isUnsigned(from) != isUnsigned(to) && !isFloat(to) {
+			x.Summary += fmt.Sprintf(" Converting %s to %s changes values that %s cannot hold, such as negative numbers.",
+				typeName(p, from), typeName(p, to), typeName(p, to))
+		}
+	default:
+		return true
+	}
+	fixed := xs + " " + bin.Op.String() + " " + ys
+	line := p.Line(e.Pos.Line)
+	if old := p.Text(bin); strings.Contains(line, old) {
+		x.Fix = strings.Replace(line, old, fixed, 1)
+	} else {
+		x.Fix = indentOf(line) + fixed
+	}
+	return true
+}
+
+// article prefixes a type name with "a" or "an".
+func article(typ string) string {
+	if strings.ContainsRune("aeiou", rune(typ[0])) {
+		return "an " + typ
+	}
+	return "a " + typ
+}
+
+// toString converts expr of type t to a string, with strconv if the file
+// imports it and with fmt otherwise.
+func toString(p *check.Package, x *Explanation, expr string, t types.Type) string {
+	if b, ok := t.Underlying().(*types.Basic); ok && b.Kind() == types.Int && imports(p.File, "strconv") {
+		return "strconv.Itoa(" + expr + ")"
+	}
+	if !imports(p.File, "fmt") {
+		x.Import = "fmt"
+	}
+	return "fmt.Sprint(" + expr + ")"
+}
+
+func isString(t types.Type) bool {
+	b, ok := t.Underlying().(*types.Basic)
+	return ok && b.Info()&types.IsString != 0
+}
+
+func isNumeric(t types.Type) bool {
+	b, ok := t.Underlying().(*types.Basic)
+	return ok && b.Info()&types.IsNumeric != 0
+}
+
+func isUnsigned(t types.Type) bool {
+	b, ok := t.Underlying().(*types.Basic)
+	return ok && b.Info()&types.IsUnsigned != 0
+}
+
+func isFloat(t types.Type) bool {
+	b, ok := t.Underlying().(*types.Basic)
+	return ok && b.Info()&(types.IsFloat|types.IsComplex) != 0
+}
+
+// wider reports whether u holds more values than t: floats hold more than
+// integers, complex numbers more than floats, and within each kind the
+// larger type wins.
+func wider(u, t types.Type) bool {
+	kind := func(t types.Type) int {
+		info := t.Underlying().(*types.Basic).Info()
+		switch {
+		case info&types.IsComplex != 0:
+			return 2
+		case info&types.IsFloat != 0:
+			return 1
+		}
+		return 0
+	}
+	if kind(u) != kind(t) {
+		return kind(u) > kind(t)
+	}
+	return sizes.Sizeof(u) > sizes.Sizeof(t)
+}
+
+var sizes = types.SizesFor("gc", runtime.GOARCH)
+
+// typeName spells t as the lesson would.
+func typeName(p *check.Package, t types.Type) string {
+	return types.TypeString(t, types.RelativeTo(p.Pkg))
+}
+
+// binaryAt returns the innermost binary expression containing pos.
+func binaryAt(p *check.Package, pos token.Position) *ast.BinaryExpr {
+	var found *ast.BinaryExpr
+	ast.Inspect(p.File, func(n ast.Node) bool {
+		if n == nil || !contains(p.Fset, n, pos) {
+			return false
+		}
+		if b, ok := n.(*ast.BinaryExpr); ok {
+			found = b
+		}
+		return true
+	})
+	return found
+}
+
+// pathTo returns the chain of nodes from f down to the identifier at pos.
+func pathTo(f *ast.File, fset *token.FileSet, pos token.Position) []ast.Node {
+	var path []ast.Node
+	ast.Inspect(f, func(n ast.Node) bool {
+		if n == nil || !contains(fset, n, pos) {
+			return false
+		}
+		path = append(path, n)
+		_, isIdent := n.(*ast.Ident)
+		return !isIdent
+	})
+	return path
+}
+
+func contains(fset *token.FileSet, n ast.Node, pos token.Position) bool {
+	start, end := fset.Position(n.Pos()), fset.Position(n.End())
+	return start.Offset <= pos.Offset && pos.Offset < end.Offset
+}
+
+func isLHS(as *ast.AssignStmt, n ast.Node) bool {
+	for _, l := range as.Lhs {
+		if l == n {
+			return true
+		}
+	}
+	return false
+}
+
+func lhsText(p *check.Package, as *ast.AssignStmt) string {
+	return exprList(p, as.Lhs)
+}
+
+func rhsText(p *check.Package, as *ast.AssignStmt) string {
+	return exprList(p, as.Rhs)
+}
+
+func exprList(p *check.Package, list []ast.Expr) string {
+	var parts []string
+	for _, e := range list {
+		parts = append(parts, p.Text(e))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// imports reports whether f imports path under its own name.
+func imports(f *ast.File, path string) bool {
+	for _, imp := range f.Imports {
+		if imp.Path.Value == strconv.Quote(path) && imp.Name == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func indentOf(line string) string {
+	return line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+}
+
+func replaceLine(src []byte, n int, text string) []byte {
+	lines := strings.Split(string(src), "\n")
+	if n >= 1 && n <= len(lines) {
+		lines[n-1] = text
+	}
+	return []byte(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,158 @@
+package explain
+
+import (
+	"strings"
+	"testing"
+
+	"example/Hello/internal/check"
+)
+
+func TestFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		class   string
+		summary string // part of the summary
+		fix     string
+		imp     string
+	}{
+		{
+			name:    "undefined string",
+			src:     "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(\"hi \" + name)\n}\n",
+			class:   "undefined",
+			summary: "It is used as a string here.",
+			fix:     "\tvar name string // declare it first, with the value you need\n\tfmt.Println(\"hi \" + name)",
+		},
+		{
+			name:    "undefined counter",
+			src:     "package main\n\nfunc main() {\n\tcount++\n}\n",
+			class:   "undefined",
+			summary: "It is used as an int here.",
+			fix:     "\tvar count int // declare it first, with the value you need\n\tcount++",
+		},
+		{
+			name:    "undefined argument",
+			src:     "package main\n\nimport \"strings\"\n\nvar s = strings.Repeat(word, 2)\n",
+			class:   "undefined",
+			summary: "It is used as a string here.",
+			fix:     "var word string // declare it first, with the value you need\nvar s = strings.Repeat(word, 2)",
+		},
+		{
+			name:    "undefined in Println",
+			src:     "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(x)\n}\n",
+			class:   "undefined",
+			summary: "declare it before this line.",
+			fix:     "\tvar x int // declare it first, with the value you need\n\tfmt.Println(x)",
+		},
+		{
+			name:    "assignment to undefined",
+			src:     "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tx = 5\n\tfmt.Println(x)\n}\n",
+			class:   "undefined",
+			summary: "A plain = only changes a variable that already exists.",
+			fix:     "\tx := 5\n\t// or: var x = 5",
+		},
+		{
+			name:    "only assigned",
+			src:     "package main\n\nfunc main() {\n\tx = 5\n}\n",
+			class:   "undefined",
+			summary: "A plain = only changes a variable that already exists.",
+			fix:     "\tx := 5\n\t// or: var x = 5\n\t_ = x",
+		},
+		{
+			name:    "short declaration outside a function",
+			src:     "package main\n\nimport \"fmt\"\n\nname := \"top\"\n\nfunc main() {\n\tfmt.Println(name)\n}\n",
+			class:   "short-decl-outside-func",
+			summary: "can only be used inside a function",
+			fix:     "var name = \"top\"",
+		},
+		{
+			name:    "declared and not used",
+			src:     "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tx := 1\n\tfmt.Println()\n}\n",
+			class:   "declared-and-not-used",
+			summary: "x is declared but never read.",
+			fix:     "\tx := 1\n\tfmt.Println(x)",
+		},
+		{
+			name:    "imported and not used",
+			src:     "package main\n\nimport \"os\"\n\nfunc main() {}\n",
+			class:   "imported-and-not-used",
+			summary: "The package \"os\" is imported but nothing in the file uses it.",
+			fix:     "// remove this line: import \"os\"",
+		},
+		{
+			name:    "int and float",
+			src:     "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tn := 1\n\tf := 2.5\n\tfmt.Println(n + f)\n}\n",
+			class:   "mismatched-types",
+			summary: "n is an int and f is a float64.",
+			fix:     "\tfmt.Println(float64(n) + f)",
+		},
+		{
+			name:    "narrow and wide",
+			src:     "package main\n\nfunc main() {\n\tvar a int64 = 1\n\tvar b int32 = 2\n\t_ = a * b\n}\n",
+			class:   "mismatched-types",
+			summary: "a is an int64 and b is an int32.",
+			fix:     "\t_ = a * int64(b)",
+		},
+		{
+			name:    "signed and unsigned",
+			src:     "package main\n\nfunc main() {\n\tvar a int\n\tvar b uint\n\t_ = a - b\n}\n",
+			class:   "mismatched-types",
+			summary: "Converting uint to int changes values that int cannot hold",
+			fix:     "\t_ = a - int(b)",
+		},
+		{
+			name:    "string and int with strconv",
+			src:     "package main\n\nimport \"strconv\"\n\nfunc main() {\n\ts, n := \"n=\", 1\n\t_ = s + n\n}\n",
+			class:   "mismatched-types",
+			summary: "s is a string and n is an int.",
+			fix:     "\t_ = s + strconv.Itoa(n)",
+		},
+		{
+			name:    "string and int without strconv",
+			src:     "package main\n\nimport \"fmt\"\n\nfunc main() {\n\ts, n := \"n=\", 1\n\tfmt.Println(s + n)\n}\n",
+			class:   "mismatched-types",
+			summary: "s is a string and n is an int.",
+			fix:     "\tfmt.Println(s + fmt.Sprint(n))",
+		},
+		{
+			name:    "string and float without imports",
+			src:     "package main\n\nfunc main() {\n\tf, s := 1.5, \"f=\"\n\t_ = f + s\n}\n",
+			class:   "mismatched-types",
+			summary: "f is a float64 and s is a string.",
+			fix:     "\t_ = fmt.Sprint(f) + s",
+			imp:     "fmt",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var x *Explanation
+			xs := File("day.go", []byte(tt.src))
+			for i := range xs {
+				if xs[i].Class == tt.class {
+					x = &xs[i]
+					break
+				}
+			}
+			if x == nil {
+				t.Fatalf("no %s explanation in %+v", tt.class, xs)
+			}
+			if !strings.Contains(x.Summary, tt.summary) {
+				t.Errorf("summary = %q, want it to contain %q", x.Summary, tt.summary)
+			}
+			if x.Fix != tt.fix {
+				t.Errorf("fix = %q, want %q", x.Fix, tt.fix)
+			}
+			if x.Import != tt.imp {
+				t.Errorf("import = %q, want %q", x.Import, tt.imp)
+			}
+
+			fixed := string(replaceLine([]byte(tt.src), x.Pos.Line, x.Fix))
+			if x.Import != "" {
+				fixed = strings.Replace(fixed, "package main\n", "package main\n\nimport \""+x.Import+"\"\n", 1)
+			}
+			if p := check.Source("day.go", []byte(fixed)); len(p.Errors) > 0 {
+				t.Errorf("fixed lesson does not compile: %v\n%s", p.Errors, fixed)
+			}
+		})
+	}
+}
@@ -0,0 +1,250 @@
+// Package fmtlab renders a value with the fmt verbs, widths, precisions
+// and flags that apply to it, so that formatting can be learned by trying.
+// Values are constants: typed literals such as int8(-5), or the values a
+// lesson gives its variables and constants where they are declared.
+package fmtlab
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"example/Hello/internal/check"
+)
+
+// Value is a value to format.
+type Value struct {
+	Expr string
+	Type string // as declared, e.g. "int8" or "Celsius"
+	V    any
+	Note string // caveats, e.g. for named types
+}
+
+// Literal evaluates expr in the package scope of p.
+func Literal(p *check.Package, expr string) (Value, error) {
+	tv, err := types.Eval(p.Fset, p.Pkg, token.NoPos, expr)
+	if err != nil {
+		return Value{}, err
+	}
+	if tv.Value == nil {
+		return Value{}, fmt.Errorf("%s is not a constant; only values of basic types can be formatted", expr)
+	}
+	return value(expr, tv.Type, tv.Value, p.Pkg)
+}
+
+// FromLesson returns the value given to the variable or constant name
+// where the lesson declares it. Only values known at compile time can be
+// formatted, and if several scopes declare name, the first declaration
+// in the file is used.
+func FromLesson(p *check.Package, name string) (Value, bool, error) {
+	var init ast.Expr
+	var obj types.Object
+	find := func(idents []*ast.Ident, values []ast.Expr) {
+		for i, id := range idents {
+			if id.Name == name && obj == nil && p.Info.Defs[id] != nil {
+				obj = p.Info.Defs[id]
+				if len(values) == len(idents) {
+					init = values[i]
+				}
+			}
+		}
+	}
+	if p.File == nil || p.Info == nil {
+		return Value{}, false, nil
+	}
+	ast.Inspect(p.File, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.ValueSpec:
+			find(n.Names, n.Values)
+		case *ast.AssignStmt:
+			if n.Tok == token.DEFINE {
+				var idents []*ast.Ident
+				for _, lhs := range n.Lhs {
+					if id, ok := lhs.(*ast.Ident); ok {
+						idents = append(idents, id)
+					}
+				}
+				find(idents, n.Rhs)
+			}
+		}
+		return obj == nil
+	})
+	if obj == nil {
+		return Value{}, false, nil
+	}
+	if c, ok := obj.(*types.Const); ok {
+		v, err := value(name, c.Type(), c.Val(), p.Pkg)
+		return v, true, err
+	}
+	if init == nil {
+		return Value{}, true, fmt.Errorf("%s is declared without a value of its own", name)
+	}
+	tv := p.Info.Types[init]
+	if tv.Value == nil {
+		return Value{}, true, fmt.Errorf("the value of %s (%s) is only known at run time", name, p.Text(init))
+	}
+	v, err := value(name, obj.Type(), tv.Value, p.Pkg)
+	return v, true, err
+}
+
+// value converts the constant c to a Go value of type t. Untyped
+// constants get their default type, as when passed to fmt.
+func value(expr string, t types.Type, c constant.Value, pkg *types.Package) (Value, error) {
+	t = types.Default(t)
+	v := Value{Expr: expr, Type: types.TypeString(t, types.RelativeTo(pkg))}
+	b, ok := t.Underlying().(*types.Basic)
+	if !ok {
+		return v, fmt.Errorf("%s has type %s; only values of basic types can be formatted", expr, v.Type)
+	}
+	if _, named := t.(*types.Named); named {
+		v.Note = fmt.Sprintf("formatted as its underlying type %s; %%T and String methods differ", b)
+	}
+	switch b.Kind() {
+	case types.Bool:
+		v.V = constant.BoolVal(c)
+	case types.String:
+		v.V = constant.StringVal(c)
+	case types.Int, types.Int8, types.Int16, types.Int32, types.Int64:
+		i, _ := constant.Int64Val(constant.ToInt(c))
+		v.V = map[types.BasicKind]any{
+			types.Int: int(i), types.Int8: int8(i), types.Int16: int16(i), types.Int32: int32(i), types.Int64: i,
+		}[b.Kind()]
+	case types.Uint, types.Uint8, types.Uint16, types.Uint32, types.Uint64, types.Uintptr:
+		u, _ := constant.Uint64Val(constant.ToInt(c))
+		v.V = map[types.BasicKind]any{
+			types.Uint: uint(u), types.Uint8: uint8(u), types.Uint16: uint16(u), types.Uint32: uint32(u), types.Uint64: u, types.Uintptr: uintptr(u),
+		}[b.Kind()]
+	case types.Float32:
+		f, _ := constant.Float32Val(constant.ToFloat(c))
+		v.V = f
+	case types.Float64:
+		f, _ := constant.Float64Val(constant.ToFloat(c))
+		v.V = f
+	case types.Complex64, types.Complex128:
+		re, _ := constant.Float64Val(constant.Real(c))
+		im, _ := constant.Float64Val(constant.Imag(c))
+		v.V = complex(re, im)
+		if b.Kind() == types.Complex64 {
+			v.V = complex64(complex(re, im))
+		}
+	default:
+		return v, fmt.Errorf("cannot format %s of type %s", expr, v.Type)
+	}
+	return v, nil
+}
+
+// Row is one way of formatting a value.
+type Row struct {
+	Format string
+	Output string
+}
+
+// verbs returns the verbs that apply to v, besides %v, %+v, %#v and %T.
+func verbs(v any) []string {
+	switch v.(type) {
+	case bool:
+		return []string{"t"}
+	case string:
+		return []string{"s", "q", "x", "X"}
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, uintptr:
+		vs := []string{"d", "b", "o", "O", "x", "X"}
+		if isRune(v) {
+			// Other integers print as U+FFFFFFFFFFFFFFFB or U+FFFD.
+			vs = append(vs, "c", "q", "U")
+		}
+		return vs
+	case float32, float64, complex64, complex128:
+		return []string{"b", "e", "E", "f", "F", "g", "G", "x", "X"}
+	}
+	return nil
+}
+
+// isRune reports whether the integer v is a valid Unicode code point.
+func isRune(v any) bool {
+	switch x := reflect.ValueOf(v); {
+	case x.CanInt():
+		return x.Int() >= 0 && x.Int() <= unicode.MaxRune && utf8.ValidRune(rune(x.Int()))
+	case x.CanUint():
+		return x.Uint() <= unicode.MaxRune && utf8.ValidRune(rune(x.Uint()))
+	}
+	return false
+}
+
+// modifiers returns the flags, widths and precisions worth trying with
+// verb on v, starting with none.
+func modifiers(verb string, v any) []string {
+	mods := []string{"", "8", "-8"}
+	_, isString := v.(string)
+	_, isBool := v.(bool)
+	numeric := !isString && !isBool
+	switch {
+	case verb == "v":
+		mods = append(mods, "+", "#")
+	case verb == "T" || verb == "t" || verb == "c":
+	case verb == "U":
+		mods = append(mods, "#")
+	case isString:
+		mods = append(mods, ".3", "8.3")
+		if verb == "x" || verb == "X" {
+			mods = append(mods, " ", "#", "# ")
+		}
+		if verb == "q" {
+			mods = append(mods, "#", "+")
+		}
+	case numeric:
+		mods = append(mods, "08", "+", " ")
+		switch verb {
+		case "e", "E", "f", "F", "g", "G":
+			mods = append(mods, ".2", "8.2", "08.2", "+.3")
+		case "b", "o", "x", "X", "q":
+			mods = append(mods, "#", "#08")
+		}
+	}
+	return mods
+}
+
+// Table formats v with every applicable verb and modifier. If verb is not
+// empty, only that verb is tried.
+func Table(v any, verb string) []Row {
+	all := append([]string{"v"}, verbs(v)...)
+	all = append(all, "T")
+	var rows []Row
+	for _, vb := range all {
+		if verb != "" && vb != verb {
+			continue
+		}
+		for _, m := range modifiers(vb, v) {
+			f := "%" + m + vb
+			rows = append(rows, Row{Format: f, Output: fmt.Sprintf(f, v)})
+		}
+	}
+	return rows
+}
+
+// Spacing shows how Print, Println and Sprint place spaces between v and
+// other operands: Println always separates operands with a space, Print
+// only when neither side is a string.
+func Spacing(v any) []Row {
+	calls := []struct {
+		call string
+		out  string
+	}{
+		{"fmt.Print(v, v)", fmt.Sprint(v, v)},
+		{"fmt.Println(v, v)", fmt.Sprintln(v, v)},
+		{`fmt.Print(v, "!")`, fmt.Sprint(v, "!")},
+		{`fmt.Println(v, "!")`, fmt.Sprintln(v, "!")},
+		{"fmt.Print(v, 1)", fmt.Sprint(v, 1)},
+		{"fmt.Println(v, 1)", fmt.Sprintln(v, 1)},
+	}
+	rows := make([]Row, len(calls))
+	for i, c := range calls {
+		rows[i] = Row{Format: c.call, Output: strings.ReplaceAll(c.out, "\n", `\n`)}
+	}
+	return rows
+}
@@ -0,0 +1,191 @@
+package fmtlab
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"example/Hello/internal/check"
+)
+
+func TestTable(t *testing.T) {
+	tests := []struct {
+		v    any
+		verb string
+		want []Row
+	}{
+		{65, "U", []Row{{"%U", "U+0041"}, {"%8U", "  U+0041"}, {"%-8U", "U+0041  "}, {"%#U", "U+0041 'A'"}}},
+		{'é', "c", []Row{{"%c", "é"}, {"%8c", "       é"}, {"%-8c", "é       "}}},
+		{int8(-5), "c", nil},
+		{int8(-5), "U", nil},
+		{uint64(1 << 40), "q", nil},
+		{true, "t", []Row{{"%t", "true"}, {"%8t", "    true"}, {"%-8t", "true    "}}},
+		{"héllo", "T", []Row{{"%T", "string"}, {"%8T", "  string"}, {"%-8T", "string  "}}},
+	}
+	for _, tt := range tests {
+		if diff := cmp.Diff(tt.want, Table(tt.v, tt.verb)); diff != "" {
+			t.Errorf("Table(%#v, %q) mismatch (-want +got):\n%s", tt.v, tt.verb, diff)
+		}
+	}
+}
+
+func TestVerbs(t *testing.T) {
+	tests := []struct {
+		v    any
+		want []string
+	}{
+		{true, []string{"t"}},
+		{"s", []string{"s", "q", "x", "X"}},
+		{65, []string{"d", "b", "o", "O", "x", "X", "c", "q", "U"}},
+		{int8(-5), []string{"d", "b", "o", "O", "x", "X"}},
+		{uint64(1 << 40), []string{"d", "b", "o", "O", "x", "X"}},
+		{0xD800, []string{"d", "b", "o", "O", "x", "X"}},
+		{2.5, []string{"b", "e", "E", "f", "F", "g", "G", "x", "X"}},
+	}
+	for _, tt := range tests {
+		if diff := cmp.Diff(tt.want, verbs(tt.v)); diff != "" {
+			t.Errorf("verbs(%#v) mismatch (-want +got):\n%s", tt.v, diff)
+		}
+	}
+}
+
+func TestModifiers(t *testing.T) {
+	tests := []struct {
+		verb string
+		v    any
+		want []string
+	}{
+		{"v", 1, []string{"", "8", "-8", "+", "#"}},
+		{"T", 1, []string{"", "8", "-8"}},
+		{"c", 65, []string{"", "8", "-8"}},
+		{"U", 65, []string{"", "8", "-8", "#"}},
+		{"d", 1, []string{"", "8", "-8", "08", "+", " "}},
+		{"x", 1, []string{"", "8", "-8", "08", "+", " ", "#", "#08"}},
+		{"f", 2.5, []string{"", "8", "-8", "08", "+", " ", ".2", "8.2", "08.2", "+.3"}},
+		{"s", "s", []string{"", "8", "-8", ".3", "8.3"}},
+		{"x", "s", []string{"", "8", "-8", ".3", "8.3", " ", "#", "# "}},
+		{"q", "s", []string{"", "8", "-8", ".3", "8.3", "#", "+"}},
+		{"t", true, []string{"", "8", "-8"}},
+	}
+	for _, tt := range tests {
+		if diff := cmp.Diff(tt.want, modifiers(tt.verb, tt.v)); diff != "" {
+			t.Errorf("modifiers(%q, %#v) mismatch (-want +got):\n%s", tt.verb, tt.v, diff)
+		}
+	}
+}
+
+func TestSpacing(t *testing.T) {
+	tests := []struct {
+		v    any
+		want []string
+	}{
+		{1, []string{"1 1", `1 1\n`, "1!", `1 !\n`, "1 1", `1 1\n`}},
+		{"a", []string{"aa", `a a\n`, "a!", `a !\n`, "a1", `a 1\n`}},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, r := range Spacing(tt.v) {
+			got = append(got, r.Output)
+		}
+		if diff := cmp.Diff(tt.want, got); diff != "" {
+			t.Errorf("Spacing(%#v) mismatch (-want +got):\n%s", tt.v, diff)
+		}
+	}
+}
+
+const lesson = `package main
+
+import "fmt"
+
+type Celsius float64
+
+const Boiling Celsius = 100
+
+const big = 1 << 40
+
+var name = "Vincent"
+
+var n int
+
+func main() {
+	age := int8(18)
+	pi := 3.14
+	count := len(name)
+	name := "shadow"
+	fmt.Println(age, pi, count, name, n)
+}
+`
+
+func TestFromLesson(t *testing.T) {
+	p := check.Source("day.go", []byte(lesson))
+	if len(p.Errors) > 0 {
+		t.Fatal(p.Errors)
+	}
+	tests := []struct {
+		name  string
+		want  Value
+		found bool
+		err   string
+	}{
+		{name: "age", want: Value{Expr: "age", Type: "int8", V: int8(18)}, found: true},
+		{name: "pi", want: Value{Expr: "pi", Type: "float64", V: 3.14}, found: true},
+		{name: "big", want: Value{Expr: "big", Type: "int", V: 1 << 40}, found: true},
+		{
+			name:  "Boiling",
+			want:  Value{Expr: "Boiling", Type: "Celsius", V: 100.0, Note: "formatted as its underlying type float64; %T and String methods differ"},
+			found: true,
+		},
+		// The package-level declaration comes first in the file.
+		{name: "name", want: Value{Expr: "name", Type: "string", V: "Vincent"}, found: true},
+		{name: "count", found: true, err: "the value of count (len(name)) is only known at run time"},
+		{name: "n", found: true, err: "n is declared without a value of its own"},
+		{name: "missing"},
+	}
+	for _, tt := range tests {
+		v, found, err := FromLesson(p, tt.name)
+		if found != tt.found {
+			t.Errorf("FromLesson(%s): found = %v, want %v", tt.name, found, tt.found)
+		}
+		if got := errString(err); got != tt.err {
+			t.Errorf("FromLesson(%s): error %q, want %q", tt.name, got, tt.err)
+		}
+		if err == nil {
+			if diff := cmp.Diff(tt.want, v); diff != "" {
+				t.Errorf("FromLesson(%s) mismatch (-want +got):\n%s", tt.name, diff)
+			}
+		}
+	}
+}
+
+func TestLiteral(t *testing.T) {
+	p := check.Source("fmtlab.go", []byte("package main\n"))
+	tests := []struct {
+		expr string
+		want Value
+		err  string
+	}{
+		{expr: "int8(-5)", want: Value{Expr: "int8(-5)", Type: "int8", V: int8(-5)}},
+		{expr: "'a'", want: Value{Expr: "'a'", Type: "rune", V: int32('a')}},
+		{expr: "uint64(1 << 40)", want: Value{Expr: "uint64(1 << 40)", Type: "uint64", V: uint64(1 << 40)}},
+		{expr: "2i", want: Value{Expr: "2i", Type: "complex128", V: 2i}},
+		{expr: "[]int{1}", err: "[]int{1} is not a constant; only values of basic types can be formatted"},
+	}
+	for _, tt := range tests {
+		v, err := Literal(p, tt.expr)
+		if got := errString(err); got != tt.err {
+			t.Errorf("Literal(%s): error %q, want %q", tt.expr, got, tt.err)
+		}
+		if err == nil {
+			if diff := cmp.Diff(tt.want, v); diff != "" {
+				t.Errorf("Literal(%s) mismatch (-want +got):\n%s", tt.expr, diff)
+			}
+		}
+	}
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
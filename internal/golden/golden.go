@@ -0,0 +1,48 @@
+// Package golden stores the expected output of lessons next to their source.
+package golden
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+
+	"example/Hello/internal/lesson"
+)
+
+// Path returns the golden file of l, e.g. "Day 4/day4.golden".
+func Path(l lesson.Lesson) string {
+	return strings.TrimSuffix(l.Main(), ".go") + ".golden"
+}
+
+// Read returns the saved output of l. It reports false if l has no
+// golden file yet.
+func Read(l lesson.Lesson) (string, bool, error) {
+	b, err := os.ReadFile(Path(l))
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return string(b), true, nil
+}
+
+// Write saves out as the expected output of l.
+func Write(l lesson.Lesson, out string) error {
+	return os.WriteFile(Path(l), []byte(out), 0o644)
+}
+
+// Diff returns a line-based diff from want to got, or "" if they match.
+func Diff(want, got string) string {
+	if want == got {
+		return ""
+	}
+	return cmp.Diff(lines(want), lines(got))
+}
+
+func lines(s string) []string {
+	return strings.SplitAfter(s, "\n")
+}
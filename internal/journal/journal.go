@@ -0,0 +1,124 @@
+// Package journal stores diary entries about a lesson in a journal.toml
+// file next to it:
+//
+//	[[entry]]
+//	time = 2025-01-06T21:30:00+08:00
+//	mood = 2
+//	energy = 1
+//	minutes = 10
+//	text = "tired"
+package journal
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/BurntSushi/toml"
+
+	"example/Hello/internal/lesson"
+)
+
+// FileName is the name of the journal in a lesson directory.
+const FileName = "journal.toml"
+
+// Entry is one journal entry. Mood and Energy are ratings from 1 (low)
+// to 5 (high); zero means not rated.
+type Entry struct {
+	Time    time.Time `toml:"time"`
+	Mood    int       `toml:"mood,omitempty"`
+	Energy  int       `toml:"energy,omitempty"`
+	Minutes int       `toml:"minutes,omitempty"`
+	Text    string    `toml:"text"`
+}
+
+// Validate checks the ratings and time spent of e.
+func (e Entry) Validate() error {
+	if e.Mood < 0 || e.Mood > 5 {
+		return fmt.Errorf("mood %d is not between 1 and 5", e.Mood)
+	}
+	if e.Energy < 0 || e.Energy > 5 {
+		return fmt.Errorf("energy %d is not between 1 and 5", e.Energy)
+	}
+	if e.Minutes < 0 {
+		return errors.New("minutes is negative")
+	}
+	return nil
+}
+
+type file struct {
+	Entries []Entry `toml:"entry"`
+}
+
+// Path returns the journal of l.
+func Path(l lesson.Lesson) string {
+	return filepath.Join(l.Dir, FileName)
+}
+
+// Read returns the entries of l, oldest first.
+func Read(l lesson.Lesson) ([]Entry, error) {
+	var f file
+	_, err := toml.DecodeFile(Path(l), &f)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	return f.Entries, err
+}
+
+// Add appends e to the journal of l.
+func Add(l lesson.Lesson, e Entry) error {
+	if err := e.Validate(); err != nil {
+		return err
+	}
+	entries, err := Read(l)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(file{Entries: append(entries, e)}); err != nil {
+		return err
+	}
+	return os.WriteFile(Path(l), buf.Bytes(), 0o644)
+}
+
+// Summary condenses the entries of one day.
+type Summary struct {
+	Day     int     `json:"day"`
+	Entries int     `json:"entries"`
+	Mood    float64 `json:"mood,omitempty"`   // average of the rated entries
+	Energy  float64 `json:"energy,omitempty"` // average of the rated entries
+	Minutes int     `json:"minutes"`
+}
+
+// Summarize condenses the entries of day.
+func Summarize(day int, entries []Entry) Summary {
+	s := Summary{Day: day, Entries: len(entries)}
+	var moods, energies int
+	for _, e := range entries {
+		s.Minutes += e.Minutes
+		if e.Mood > 0 {
+			s.Mood += float64(e.Mood)
+			moods++
+		}
+		if e.Energy > 0 {
+			s.Energy += float64(e.Energy)
+			energies++
+		}
+	}
+	if moods > 0 {
+		s.Mood /= float64(moods)
+	}
+	if energies > 0 {
+		s.Energy /= float64(energies)
+	}
+	return s
+}
+
+// LowEnergy reports whether the day was rated as low on energy.
+func (s Summary) LowEnergy() bool {
+	return s.Energy > 0 && s.Energy <= 2
+}
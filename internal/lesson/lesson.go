@@ -0,0 +1,146 @@
+// Package lesson finds the daily lessons of the journal on disk.
+//
+// Lessons live either in "Day N" directories at the module root or, after
+// weeks migrate, in days/dayNN directories whose names are valid import
+// path elements.
+package lesson
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// DaysDir is the directory of the migrated lessons, e.g. "days/day04".
+const DaysDir = "days"
+
+var (
+	// dirPattern matches lesson directories such as "Day 4".
+	dirPattern = regexp.MustCompile(`^Day (\d+)$`)
+	// pkgPattern matches migrated lesson directories such as "day04".
+	pkgPattern = regexp.MustCompile(`^day(\d+)$`)
+)
+
+// Lesson is a single day of the journal.
+type Lesson struct {
+	Day int
+	Dir string // absolute path of the lesson directory
+}
+
+// Name returns the display name of the lesson, e.g. "Day 4".
+func (l Lesson) Name() string {
+	return fmt.Sprintf("Day %d", l.Day)
+}
+
+// Main returns the path of the lesson's program, e.g. "Day 4/day4.go"
+// or "days/day04/day4.go".
+func (l Lesson) Main() string {
+	return filepath.Join(l.Dir, fmt.Sprintf("day%d.go", l.Day))
+}
+
+// Rel returns the lesson directory relative to the module root,
+// e.g. "Day 4" or "days/day04".
+func (l Lesson) Rel() string {
+	base := filepath.Base(l.Dir)
+	if filepath.Base(filepath.Dir(l.Dir)) == DaysDir && pkgPattern.MatchString(base) {
+		return filepath.Join(DaysDir, base)
+	}
+	return base
+}
+
+// PackageDir returns the migrated directory of day relative to the module
+// root, e.g. "days/day04".
+func PackageDir(day int) string {
+	return filepath.Join(DaysDir, fmt.Sprintf("day%02d", day))
+}
+
+// At returns the lesson of the given day below root, whether or not it
+// exists yet. New lessons follow the migrated layout once root has a
+// days directory.
+func At(root string, day int) Lesson {
+	if fi, err := os.Stat(filepath.Join(root, DaysDir)); err == nil && fi.IsDir() {
+		return Lesson{Day: day, Dir: filepath.Join(root, PackageDir(day))}
+	}
+	return Lesson{Day: day, Dir: filepath.Join(root, fmt.Sprintf("Day %d", day))}
+}
+
+// Discover returns every lesson below root, sorted by day. It finds the
+// lessons of both layouts and fails if a day is in both.
+func Discover(root string) ([]Lesson, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+	lessons, err := scan(root, dirPattern)
+	if err != nil {
+		return nil, err
+	}
+	migrated, err := scan(filepath.Join(root, DaysDir), pkgPattern)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	lessons = append(lessons, migrated...)
+	sort.Slice(lessons, func(i, j int) bool { return lessons[i].Day < lessons[j].Day })
+	for i := 1; i < len(lessons); i++ {
+		if lessons[i].Day == lessons[i-1].Day {
+			return nil, fmt.Errorf("day %d is in both %s and %s", lessons[i].Day, lessons[i-1].Rel(), lessons[i].Rel())
+		}
+	}
+	return lessons, nil
+}
+
+// scan returns the lessons in the directories of dir matching pattern.
+func scan(dir string, pattern *regexp.Regexp) ([]Lesson, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var lessons []Lesson
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		m := pattern.FindStringSubmatch(e.Name())
+		if m == nil {
+			continue
+		}
+		day, err := strconv.Atoi(m[1])
+		if err != nil {
+			continue
+		}
+		lessons = append(lessons, Lesson{Day: day, Dir: filepath.Join(dir, e.Name())})
+	}
+	return lessons, nil
+}
+
+// Select returns the lessons whose day is listed in days.
+// An empty days returns all lessons.
+func Select(lessons []Lesson, days []int) ([]Lesson, error) {
+	if len(days) == 0 {
+		return lessons, nil
+	}
+	byDay := make(map[int]Lesson, len(lessons))
+	for _, l := range lessons {
+		byDay[l.Day] = l
+	}
+	var out []Lesson
+	for _, d := range days {
+		l, ok := byDay[d]
+		if !ok {
+			return nil, fmt.Errorf("no lesson for day %d", d)
+		}
+		out = append(out, l)
+	}
+	return out, nil
+}
+
+// Next returns the day after the last lesson, or 1 if there is none.
+func Next(lessons []Lesson) int {
+	if len(lessons) == 0 {
+		return 1
+	}
+	return lessons[len(lessons)-1].Day + 1
+}
@@ -0,0 +1,126 @@
+// Package meta reads the optional lesson.toml metadata of a lesson.
+//
+// A lesson.toml looks like this:
+//
+//	title = "Variables"
+//	date = 2025-01-04
+//	topics = ["variables", "short-declaration"]
+//	prerequisites = [2]
+//	minutes = 30
+//	status = "done"
+package meta
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+
+	"example/Hello/internal/lesson"
+)
+
+// FileName is the name of the metadata file in a lesson directory.
+const FileName = "lesson.toml"
+
+// Status is the state of a lesson.
+type Status string
+
+const (
+	StatusDraft  Status = "draft"
+	StatusDone   Status = "done"
+	StatusBroken Status = "broken"
+)
+
+// Meta is the metadata of a lesson.
+type Meta struct {
+	Title         string    `toml:"title"`
+	Date          time.Time `toml:"date,omitempty"`
+	Topics        []string  `toml:"topics"`
+	Prerequisites []int     `toml:"prerequisites,omitempty"`
+	Minutes       int       `toml:"minutes,omitempty"`
+	Status        Status    `toml:"status"`
+
+	undecoded []string // keys that are not part of the schema
+}
+
+// Path returns the metadata file of l.
+func Path(l lesson.Lesson) string {
+	return filepath.Join(l.Dir, FileName)
+}
+
+// Read returns the metadata of l. It reports false if l has none.
+func Read(l lesson.Lesson) (*Meta, bool, error) {
+	var m Meta
+	md, err := toml.DecodeFile(Path(l), &m)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	for _, k := range md.Undecoded() {
+		m.undecoded = append(m.undecoded, k.String())
+	}
+	return &m, true, nil
+}
+
+// HasTopic reports whether m lists topic.
+func (m *Meta) HasTopic(topic string) bool {
+	for _, t := range m.Topics {
+		if strings.EqualFold(t, topic) {
+			return true
+		}
+	}
+	return false
+}
+
+// Lint checks m, the metadata of day, against the schema. days holds
+// the days that have a lesson, to check prerequisites.
+func (m *Meta) Lint(day int, days map[int]bool) []string {
+	var problems []string
+	add := func(format string, args ...any) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+	for _, k := range m.undecoded {
+		add("unknown key %q", k)
+	}
+	if strings.TrimSpace(m.Title) == "" {
+		add("title is empty")
+	}
+	switch m.Status {
+	case StatusDraft, StatusDone, StatusBroken:
+	case "":
+		add("status is missing (want draft, done or broken)")
+	default:
+		add("status %q is not one of draft, done or broken", m.Status)
+	}
+	if !m.Date.IsZero() && m.Date.After(time.Now()) {
+		add("date %s is in the future", m.Date.Format(time.DateOnly))
+	}
+	if m.Minutes < 0 {
+		add("minutes is negative")
+	}
+	seen := make(map[string]bool)
+	for _, t := range m.Topics {
+		switch {
+		case strings.TrimSpace(t) == "":
+			add("empty topic")
+		case seen[strings.ToLower(t)]:
+			add("duplicate topic %q", t)
+		}
+		seen[strings.ToLower(t)] = true
+	}
+	for _, p := range m.Prerequisites {
+		switch {
+		case p >= day:
+			add("prerequisite day %d is not before day %d", p, day)
+		case !days[p]:
+			add("prerequisite day %d has no lesson", p)
+		}
+	}
+	return problems
+}
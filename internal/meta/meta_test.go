@@ -0,0 +1,63 @@
+package meta
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"example/Hello/internal/lesson"
+)
+
+func TestLint(t *testing.T) {
+	days := map[int]bool{2: true, 3: true, 4: true}
+	valid := func() Meta {
+		return Meta{Title: "Variables", Status: StatusDone, Topics: []string{"var-decl"}, Prerequisites: []int{2}}
+	}
+	tests := []struct {
+		name   string
+		change func(m *Meta)
+		want   []string
+	}{
+		{"valid", func(m *Meta) {}, nil},
+		{"empty title", func(m *Meta) { m.Title = " " }, []string{"title is empty"}},
+		{"missing status", func(m *Meta) { m.Status = "" }, []string{"status is missing (want draft, done or broken)"}},
+		{"bad status", func(m *Meta) { m.Status = "wip" }, []string{`status "wip" is not one of draft, done or broken`}},
+		{"future date", func(m *Meta) { m.Date = time.Date(2999, 1, 2, 0, 0, 0, 0, time.UTC) }, []string{"date 2999-01-02 is in the future"}},
+		{"negative minutes", func(m *Meta) { m.Minutes = -1 }, []string{"minutes is negative"}},
+		{"topics", func(m *Meta) { m.Topics = []string{"a", "", "A"} }, []string{"empty topic", `duplicate topic "A"`}},
+		{"prerequisites", func(m *Meta) { m.Prerequisites = []int{1, 4} }, []string{"prerequisite day 1 has no lesson", "prerequisite day 4 is not before day 4"}},
+		{"unknown key", func(m *Meta) { m.undecoded = []string{"tags"} }, []string{`unknown key "tags"`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := valid()
+			tt.change(&m)
+			if diff := cmp.Diff(tt.want, m.Lint(4, days)); diff != "" {
+				t.Errorf("Lint mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRead(t *testing.T) {
+	l := lesson.Lesson{Day: 4, Dir: t.TempDir()}
+	if _, ok, err := Read(l); ok || err != nil {
+		t.Fatalf("Read without lesson.toml = %v, %v; want false, nil", ok, err)
+	}
+	src := "title = \"Variables\"\ndate = 2025-01-06\ntopics = [\"var-decl\"]\nstatus = \"done\"\ntags = [\"x\"]\n"
+	if err := os.WriteFile(Path(l), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m, ok, err := Read(l)
+	if !ok || err != nil {
+		t.Fatalf("Read = %v, %v", ok, err)
+	}
+	if m.Title != "Variables" || !m.HasTopic("VAR-DECL") || m.Date.Format(time.DateOnly) != "2025-01-06" {
+		t.Errorf("Read = %+v", m)
+	}
+	if diff := cmp.Diff([]string{`unknown key "tags"`}, m.Lint(4, nil)); diff != "" {
+		t.Errorf("Lint mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,198 @@
+// Package migrate moves lessons from "Day N" directories to days/dayNN.
+// Directory names with spaces are not valid import path elements, so
+// go build ./..., go vet ./... and go test ./... skip the old layout.
+package migrate
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"example/Hello/internal/expect"
+	"example/Hello/internal/lesson"
+	"example/Hello/internal/notes"
+)
+
+// Move relocates one lesson. From and To are relative to the module root.
+type Move struct {
+	Day      int
+	From, To string
+}
+
+// Plan returns the moves that bring every lesson below root to the new
+// layout. Lessons that are already migrated are left alone.
+func Plan(root string) ([]Move, error) {
+	lessons, err := lesson.Discover(root)
+	if err != nil {
+		return nil, err
+	}
+	var moves []Move
+	for _, l := range lessons {
+		to := lesson.PackageDir(l.Day)
+		if l.Rel() == to {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(root, to)); err == nil {
+			return nil, fmt.Errorf("cannot move %s: %s already exists", l.Rel(), to)
+		}
+		moves = append(moves, Move{Day: l.Day, From: l.Rel(), To: to})
+	}
+	return moves, nil
+}
+
+// Apply performs moves with git mv, so that git keeps the history of
+// the lessons. Files that git does not track are moved along with the
+// directory.
+func Apply(root string, moves []Move) error {
+	if len(moves) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Join(root, lesson.DaysDir), 0o755); err != nil {
+		return err
+	}
+	for _, m := range moves {
+		cmd := exec.Command("git", "mv", "-k", m.From, m.To)
+		cmd.Dir = root
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("git mv %s: %v\n%s", m.From, err, out)
+		}
+		// git mv -k skips untracked files; move what is left by hand.
+		if _, err := os.Stat(filepath.Join(root, m.From)); err == nil {
+			if err := moveRest(filepath.Join(root, m.From), filepath.Join(root, m.To)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// moveRest moves the files left in from to to and removes from.
+func moveRest(from, to string) error {
+	err := filepath.WalkDir(from, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(from, path)
+		if err != nil {
+			return err
+		}
+		dst := filepath.Join(to, rel)
+		if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+			return err
+		}
+		return os.Rename(path, dst)
+	})
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(from)
+}
+
+// RewriteNotes updates the file names in the notes knowledge base at path
+// and reports how many notes changed. A missing knowledge base is not an
+// error.
+func RewriteNotes(path string, moves []Move, dryRun bool) (int, error) {
+	all, err := notes.Load(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for i, note := range all {
+		for _, m := range moves {
+			if p, ok := rebase(note.File, m); ok {
+				all[i].File = p
+				n++
+				break
+			}
+		}
+	}
+	if n == 0 || dryRun {
+		return n, nil
+	}
+	return n, notes.Save(path, all)
+}
+
+// rebase moves file from m.From to m.To if it is inside m.From.
+func rebase(file string, m Move) (string, bool) {
+	from := filepath.ToSlash(m.From) + "/"
+	if !strings.HasPrefix(file, from) {
+		return file, false
+	}
+	return filepath.ToSlash(m.To) + "/" + strings.TrimPrefix(file, from), true
+}
+
+// Warnings lists what will still keep go build ./... from succeeding
+// after the migration: lessons that are meant not to compile, and Go
+// files below root that belong to no module, such as a module cache.
+func Warnings(root string) ([]string, error) {
+	lessons, err := lesson.Discover(root)
+	if err != nil {
+		return nil, err
+	}
+	var warnings []string
+	for _, l := range lessons {
+		src, err := os.ReadFile(l.Main())
+		if err != nil {
+			return nil, err
+		}
+		spec, err := expect.Parse(src)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", l.Name(), err)
+		}
+		if !spec.Empty() {
+			warnings = append(warnings, fmt.Sprintf("%s has weeks:expect-error markers and will not compile with go build ./...", l.Name()))
+		}
+	}
+	if HasModCache(root) {
+		warnings = append(warnings, ModCache+" is a module cache inside the module, so ./... patterns reach into it and fail; "+
+			"move it out with weeks migrate -move-modcache dir, or by hand with git rm -r -q --cached "+ModCache+" && mv "+ModCache+" dir")
+	}
+	return warnings, nil
+}
+
+// ModCache is where a module cache ends up inside the module when GOPATH
+// points at the module root.
+const ModCache = "pkg/mod"
+
+// HasModCache reports whether root holds a module cache.
+func HasModCache(root string) bool {
+	fi, err := os.Stat(filepath.Join(root, filepath.FromSlash(ModCache)))
+	return err == nil && fi.IsDir()
+}
+
+// MoveModCache moves the module cache out of root to the new directory
+// to and stops git from tracking it. to must not exist yet and must be
+// outside root.
+func MoveModCache(root, to string) error {
+	to, err := filepath.Abs(to)
+	if err != nil {
+		return err
+	}
+	if rel, err := filepath.Rel(root, to); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("%s is inside the module", to)
+	}
+	if _, err := os.Stat(to); err == nil {
+		return fmt.Errorf("%s already exists", to)
+	}
+	cmd := exec.Command("git", "rm", "-r", "-q", "--cached", "--ignore-unmatch", ModCache)
+	cmd.Dir = root
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git rm %s: %v\n%s", ModCache, err, out)
+	}
+	if err := os.MkdirAll(filepath.Dir(to), 0o755); err != nil {
+		return err
+	}
+	if err := os.Rename(filepath.Join(root, filepath.FromSlash(ModCache)), to); err != nil {
+		return fmt.Errorf("%v; pick a directory on the same file system", err)
+	}
+	// Remove pkg if the cache was all it held.
+	os.Remove(filepath.Join(root, filepath.Dir(filepath.FromSlash(ModCache))))
+	return nil
+}
@@ -0,0 +1,215 @@
+// Package notes extracts the teaching notes written as comments in
+// lessons into a searchable knowledge base.
+package notes
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/token"
+	"os"
+	"sort"
+	"strings"
+
+	"example/Hello/internal/check"
+)
+
+// FileName is the knowledge base at the module root.
+const FileName = "notes.json"
+
+// Note is one comment group of a lesson.
+type Note struct {
+	Day       int    `json:"day"`
+	File      string `json:"file"`
+	Line      int    `json:"line"`
+	Topic     string `json:"topic"`
+	Construct string `json:"construct,omitempty"` // the code the note belongs to
+	Text      string `json:"text"`
+}
+
+// keywords choose the topic of a note from its text, before falling
+// back to the kind of code it is attached to.
+var keywords = []struct{ word, topic string }{
+	{":=", "short-var-decl"},
+	{"concatenat", "string-concatenation"},
+	{"const", "constants"},
+	{"comment", "comments"},
+	{"type", "types"},
+	{"declare", "var-decl"},
+	{"var ", "var-decl"},
+}
+
+// Extract returns the notes of a lesson for the given day.
+func Extract(day int, p *check.Package) []Note {
+	if p.File == nil {
+		return nil
+	}
+	constructs := collect(p.File)
+	var notes []Note
+	for _, cg := range p.File.Comments {
+		text := commentText(cg)
+		if text == "" {
+			continue
+		}
+		start := p.Fset.Position(cg.Pos())
+		n := Note{Day: day, File: start.Filename, Line: start.Line, Text: text}
+		if c := nearest(p.Fset, constructs, cg); c != nil {
+			n.Construct = strings.TrimSpace(strings.SplitN(p.Text(c), "\n", 2)[0])
+			n.Topic = topicOf(c)
+		}
+		lower := strings.ToLower(text)
+		for _, k := range keywords {
+			if strings.Contains(lower, k.word) {
+				n.Topic = k.topic
+				break
+			}
+		}
+		if n.Topic == "" {
+			n.Topic = "general"
+		}
+		notes = append(notes, n)
+	}
+	return notes
+}
+
+// commentText returns the text of a comment group without comment
+// markers and weeks directives.
+func commentText(cg *ast.CommentGroup) string {
+	var lines []string
+	for _, c := range cg.List {
+		t := c.Text
+		switch {
+		case strings.HasPrefix(t, "//"):
+			t = strings.TrimSpace(strings.TrimPrefix(t, "//"))
+			if strings.HasPrefix(t, "weeks:") {
+				continue
+			}
+			lines = append(lines, t)
+		default:
+			t = strings.TrimSuffix(strings.TrimPrefix(t, "/*"), "*/")
+			for _, l := range strings.Split(t, "\n") {
+				if l = strings.TrimSpace(l); l != "" {
+					lines = append(lines, l)
+				}
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// collect returns the declarations and statements of f in source order.
+func collect(f *ast.File) []ast.Node {
+	var nodes []ast.Node
+	for _, d := range f.Decls {
+		nodes = append(nodes, d)
+		fd, ok := d.(*ast.FuncDecl)
+		if !ok || fd.Body == nil {
+			continue
+		}
+		ast.Inspect(fd.Body, func(n ast.Node) bool {
+			if st, ok := n.(ast.Stmt); ok {
+				if _, isBlock := st.(*ast.BlockStmt); !isBlock {
+					nodes = append(nodes, st)
+				}
+			}
+			return true
+		})
+	}
+	sort.SliceStable(nodes, func(i, j int) bool { return nodes[i].Pos() < nodes[j].Pos() })
+	return nodes
+}
+
+// nearest picks the construct a comment belongs to: the code on the
+// same line, else the next code after it, else the code right before.
+func nearest(fset *token.FileSet, nodes []ast.Node, cg *ast.CommentGroup) ast.Node {
+	line := fset.Position(cg.Pos()).Line
+	var before, after ast.Node
+	for _, n := range nodes {
+		if _, ok := n.(*ast.FuncDecl); ok && n.Pos() < cg.Pos() && cg.End() < n.End() {
+			continue // the function around the comment is too coarse
+		}
+		switch {
+		case fset.Position(n.End()).Line == line && n.Pos() < cg.Pos():
+			return n
+		case n.Pos() > cg.End() && after == nil:
+			after = n
+		case n.End() < cg.Pos():
+			before = n
+		}
+	}
+	if after != nil {
+		return after
+	}
+	return before
+}
+
+func topicOf(n ast.Node) string {
+	switch n := n.(type) {
+	case *ast.AssignStmt:
+		if n.Tok == token.DEFINE {
+			return "short-var-decl"
+		}
+		return "assignment"
+	case *ast.DeclStmt:
+		return topicOf(n.Decl)
+	case *ast.GenDecl:
+		switch n.Tok {
+		case token.VAR:
+			return "var-decl"
+		case token.CONST:
+			return "constants"
+		case token.IMPORT:
+			return "imports"
+		}
+		return "types"
+	case *ast.FuncDecl:
+		return "functions"
+	case *ast.ExprStmt:
+		return "printing"
+	case *ast.IfStmt, *ast.SwitchStmt, *ast.ForStmt, *ast.RangeStmt:
+		return "control-flow"
+	}
+	return ""
+}
+
+// Load reads the knowledge base at path.
+func Load(path string) ([]Note, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var notes []Note
+	return notes, json.Unmarshal(b, &notes)
+}
+
+// Save writes the knowledge base to path.
+func Save(path string, notes []Note) error {
+	b, err := json.MarshalIndent(notes, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(b, '\n'), 0o644)
+}
+
+// Search returns the notes that contain every word of query in their
+// text, topic or construct. day and topic narrow the search if set.
+func Search(notes []Note, query string, day int, topic string) []Note {
+	words := strings.Fields(strings.ToLower(query))
+	var out []Note
+	for _, n := range notes {
+		if day != 0 && n.Day != day || topic != "" && !strings.EqualFold(n.Topic, topic) {
+			continue
+		}
+		hay := strings.ToLower(n.Text + "\n" + n.Topic + "\n" + n.Construct)
+		match := true
+		for _, w := range words {
+			if !strings.Contains(hay, w) {
+				match = false
+				break
+			}
+		}
+		if match {
+			out = append(out, n)
+		}
+	}
+	return out
+}
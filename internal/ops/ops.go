@@ -0,0 +1,196 @@
+// Package ops explores what Go's binary operators do with operands of the
+// basic types and with untyped constants. Each combination is written out
+// as a tiny program and type checked, so results and errors are the
+// compiler's own.
+package ops
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+
+	"example/Hello/internal/check"
+)
+
+// Operators are the binary operators.
+var Operators = []string{
+	"+", "-", "*", "/", "%",
+	"&", "|", "^", "&^", "<<", ">>",
+	"==", "!=", "<", "<=", ">", ">=",
+	"&&", "||",
+}
+
+// Types are the operand types: the basic types, and untyped constants
+// named "untyped-int" and so on.
+var Types = []string{
+	"bool", "string",
+	"int", "int8", "int16", "int32", "int64",
+	"uint", "uint8", "uint16", "uint32", "uint64", "uintptr",
+	"float32", "float64", "complex64", "complex128",
+	"byte", "rune",
+	"untyped-bool", "untyped-int", "untyped-rune", "untyped-float", "untyped-complex", "untyped-string",
+}
+
+// literals stand in for untyped constant operands.
+var literals = map[string]string{
+	"untyped-bool":    "true",
+	"untyped-int":     "2",
+	"untyped-rune":    "'a'",
+	"untyped-float":   "2.5",
+	"untyped-complex": "2i",
+	"untyped-string":  `"s"`,
+}
+
+// Result is the outcome of applying an operator to two operands.
+type Result struct {
+	Pos  token.Position // of the expression, when found in a lesson
+	Expr string
+	Op   string
+	X, Y string // operand types
+	Type string // result type, if it compiles
+	Err  string // the compiler error otherwise
+}
+
+// OK reports whether the expression compiles.
+func (r Result) OK() bool { return r.Err == "" }
+
+// Check applies op to operands of types x and y.
+func Check(op, x, y string) (Result, error) {
+	if !slices.Contains(Operators, op) {
+		return Result{}, fmt.Errorf("unknown operator %q; want one of %s", op, strings.Join(Operators, " "))
+	}
+	for _, t := range []string{x, y} {
+		if !slices.Contains(Types, t) {
+			return Result{}, fmt.Errorf("unknown type %q; want one of %s", t, strings.Join(Types, " "))
+		}
+	}
+	var src strings.Builder
+	src.WriteString("package p\n\n")
+	operand := func(name, t string) string {
+		if lit, ok := literals[t]; ok {
+			return lit
+		}
+		fmt.Fprintf(&src, "var %s %s\n", name, t)
+		return name
+	}
+	expr := operand("a", x) + " " + op + " " + operand("b", y)
+	// A variable would give an untyped result its default type.
+	decl := "var"
+	if literals[x] != "" && literals[y] != "" {
+		decl = "const"
+	}
+	fmt.Fprintf(&src, "\n%s _ = %s\n", decl, expr)
+
+	p := check.Source("ops.go", []byte(src.String()))
+	r := Result{Expr: expr, Op: op, X: x, Y: y}
+	for _, e := range p.Errors {
+		r.Err = e.Msg
+		return r, nil
+	}
+	ast.Inspect(p.File, func(n ast.Node) bool {
+		if b, ok := n.(*ast.BinaryExpr); ok && r.Type == "" {
+			r.Type = typeName(p.Info.Types[b].Type)
+		}
+		return r.Type == ""
+	})
+	return r, nil
+}
+
+// InFile returns the binary expressions of p, or those on line if it is
+// not zero, with the types of their operands.
+func InFile(p *check.Package, line int) []Result {
+	if p.File == nil || p.Info == nil {
+		return nil
+	}
+	var out []Result
+	ast.Inspect(p.File, func(n ast.Node) bool {
+		b, ok := n.(*ast.BinaryExpr)
+		if !ok {
+			return true
+		}
+		pos := p.Fset.Position(b.Pos())
+		if line != 0 && (pos.Line > line || p.Fset.Position(b.End()).Line < line) {
+			return true
+		}
+		r := Result{
+			Pos:  pos,
+			Expr: p.Text(b),
+			Op:   b.Op.String(),
+			X:    typeName(p.Info.Types[b.X].Type),
+			Y:    typeName(p.Info.Types[b.Y].Type),
+			Type: typeName(p.Info.Types[b].Type),
+		}
+		for _, e := range p.Errors {
+			if e.Pos.Offset >= pos.Offset && e.Pos.Offset < p.Fset.Position(b.End()).Offset {
+				r.Err, r.Type = e.Msg, ""
+				break
+			}
+		}
+		out = append(out, r)
+		return true
+	})
+	return out
+}
+
+// typeName spells untyped types the way Types does.
+func typeName(t types.Type) string {
+	if t == nil {
+		return ""
+	}
+	if b, ok := t.(*types.Basic); ok && b.Info()&types.IsUntyped != 0 {
+		return strings.Replace(b.Name(), " ", "-", 1)
+	}
+	return t.String()
+}
+
+// Note says in a few words what the operator of r does, if there is
+// something worth knowing. It goes by the result type, so that
+// untyped-int + float64 is float64 arithmetic; comparisons go by the
+// operand types.
+func Note(r Result) string {
+	op, t := r.Op, r.Type
+	if t == "" || isComparison(op) {
+		t = r.X
+		if strings.HasPrefix(t, "untyped-") {
+			t = r.Y
+		}
+	}
+	t, untyped := strings.CutPrefix(t, "untyped-")
+	str := t == "string"
+	integer := strings.HasPrefix(t, "int") || strings.HasPrefix(t, "uint") || t == "byte" || t == "rune"
+	float := strings.HasPrefix(t, "float")
+	switch {
+	case op == "+" && str:
+		return "concatenates the strings"
+	case op == "/" && integer:
+		return "integer division, truncates toward zero"
+	case op == "%" && integer:
+		return "remainder, with the sign of the left operand"
+	case op == "&^":
+		return "bit clear: a AND NOT b"
+	case op == "^":
+		return "bitwise exclusive or"
+	case op == "<<" || op == ">>":
+		return "shift; the count must be an integer"
+	case isComparison(op) && str:
+		return "compares byte by byte"
+	case isComparison(op):
+		return "yields an untyped bool, which becomes bool when assigned"
+	case (op == "+" || op == "-" || op == "*") && integer && untyped:
+		return "exact; the result must fit where it is used"
+	case (op == "+" || op == "-" || op == "*") && integer:
+		return "wraps around on overflow at run time"
+	case op == "/" && float && !untyped:
+		return "floating-point division"
+	case op == "&&" || op == "||":
+		return "short-circuits: the right operand may not be evaluated"
+	}
+	return ""
+}
+
+func isComparison(op string) bool {
+	return slices.Contains([]string{"==", "!=", "<", "<=", ">", ">="}, op)
+}
@@ -0,0 +1,33 @@
+package ops
+
+import "testing"
+
+func TestNote(t *testing.T) {
+	tests := []struct {
+		op, x, y string
+		want     string
+	}{
+		{"+", "untyped-int", "untyped-int", "exact; the result must fit where it is used"},
+		{"+", "untyped-int", "float64", ""},
+		{"+", "untyped-int", "int8", "wraps around on overflow at run time"},
+		{"/", "untyped-int", "float64", "floating-point division"},
+		{"/", "untyped-float", "untyped-int", ""},
+		{"/", "untyped-int", "int", "integer division, truncates toward zero"},
+		{"+", "string", "untyped-string", "concatenates the strings"},
+		{"<", "untyped-string", "string", "compares byte by byte"},
+		{"==", "int", "untyped-int", "yields an untyped bool, which becomes bool when assigned"},
+	}
+	for _, tt := range tests {
+		r, err := Check(tt.op, tt.x, tt.y)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !r.OK() {
+			t.Errorf("%s %s %s: %s", tt.x, tt.op, tt.y, r.Err)
+			continue
+		}
+		if got := Note(r); got != tt.want {
+			t.Errorf("Note(%s %s %s) = %q, want %q", tt.x, tt.op, tt.y, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+// Package report writes lesson results in machine-readable formats:
+// JSON lines, JUnit XML and TAP.
+package report
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"example/Hello/internal/diag"
+	"example/Hello/internal/runner"
+)
+
+// Record is the result of one lesson.
+type Record struct {
+	Lesson      string       `json:"lesson"`
+	Day         int          `json:"day"`
+	File        string       `json:"file"`
+	Status      string       `json:"status"`
+	Passed      bool         `json:"passed"`
+	ExitCode    int          `json:"exit_code"`
+	DurationMS  float64      `json:"duration_ms"`
+	Stdout      string       `json:"stdout,omitempty"`
+	Stderr      string       `json:"stderr,omitempty"`
+	Diagnostics []Diagnostic `json:"diagnostics,omitempty"`
+}
+
+// Diagnostic is a compiler message with its file relative to the
+// module root.
+type Diagnostic struct {
+	diag.Diagnostic
+	// Expected is set for errors announced by weeks:expect-error markers.
+	Expected bool `json:"expected,omitempty"`
+}
+
+// Records converts runner results; paths are made relative to root.
+func Records(root string, results []runner.Result) []Record {
+	var recs []Record
+	for _, res := range results {
+		file := rel(root, res.Lesson.Main())
+		rec := Record{
+			Lesson:     res.Lesson.Name(),
+			Day:        res.Lesson.Day,
+			File:       file,
+			Status:     string(res.Status),
+			Passed:     res.Passed(),
+			ExitCode:   res.ExitCode,
+			DurationMS: float64(res.Duration.Microseconds()) / 1000,
+			Stdout:     res.Stdout,
+			Stderr:     res.Stderr,
+		}
+		for _, d := range diag.Parse(res.BuildOutput) {
+			expected := res.Spec.Expects(d)
+			d.File = filepath.ToSlash(filepath.Join(filepath.Dir(file), d.File))
+			rec.Diagnostics = append(rec.Diagnostics, Diagnostic{
+				Diagnostic: d,
+				Expected:   expected,
+			})
+		}
+		recs = append(recs, rec)
+	}
+	return recs
+}
+
+func rel(root, path string) string {
+	if r, err := filepath.Rel(root, path); err == nil {
+		return filepath.ToSlash(r)
+	}
+	return filepath.ToSlash(path)
+}
+
+// JSON writes one JSON object per record and line.
+func JSON(w io.Writer, recs []Record) error {
+	enc := json.NewEncoder(w)
+	for _, r := range recs {
+		if err := enc.Encode(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type junitSuites struct {
+	XMLName xml.Name     `xml:"testsuites"`
+	Suites  []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Time     string      `xml:"time,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	File      string        `xml:"file,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+	SystemErr string        `xml:"system-err,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// JUnit writes the records as a JUnit XML test suite.
+func JUnit(w io.Writer, recs []Record) error {
+	suite := junitSuite{Name: "weeks", Tests: len(recs)}
+	var total float64
+	for _, r := range recs {
+		total += r.DurationMS
+		c := junitCase{
+			Name:      r.Lesson,
+			ClassName: "weeks",
+			File:      r.File,
+			Time:      seconds(r.DurationMS),
+			SystemOut: r.Stdout,
+			SystemErr: r.Stderr,
+		}
+		if !r.Passed {
+			suite.Failures++
+			var body strings.Builder
+			for _, d := range r.Diagnostics {
+				fmt.Fprintln(&body, d.String())
+			}
+			c.Failure = &junitFailure{
+				Message: fmt.Sprintf("%s (exit code %d)", r.Status, r.ExitCode),
+				Type:    r.Status,
+				Body:    body.String(),
+			}
+		}
+		suite.Cases = append(suite.Cases, c)
+	}
+	suite.Time = seconds(total)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(junitSuites{Suites: []junitSuite{suite}}); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func seconds(ms float64) string {
+	return fmt.Sprintf("%.3f", ms/1000)
+}
+
+// TAP writes the records in the Test Anything Protocol, version 13,
+// with the details of each lesson in a YAML block.
+func TAP(w io.Writer, recs []Record) error {
+	var b strings.Builder
+	fmt.Fprintln(&b, "TAP version 13")
+	fmt.Fprintf(&b, "1..%d\n", len(recs))
+	for i, r := range recs {
+		ok := "ok"
+		if !r.Passed {
+			ok = "not ok"
+		}
+		fmt.Fprintf(&b, "%s %d - %s\n", ok, i+1, r.Lesson)
+		fmt.Fprintln(&b, "  ---")
+		fmt.Fprintf(&b, "  file: %s\n", yamlString(r.File))
+		fmt.Fprintf(&b, "  status: %s\n", r.Status)
+		fmt.Fprintf(&b, "  exit_code: %d\n", r.ExitCode)
+		fmt.Fprintf(&b, "  duration_ms: %.3f\n", r.DurationMS)
+		if len(r.Diagnostics) > 0 {
+			fmt.Fprintln(&b, "  diagnostics:")
+			for _, d := range r.Diagnostics {
+				fmt.Fprintf(&b, "    - file: %s\n", yamlString(d.File))
+				fmt.Fprintf(&b, "      line: %d\n", d.Line)
+				fmt.Fprintf(&b, "      column: %d\n", d.Col)
+				fmt.Fprintf(&b, "      message: %s\n", yamlString(d.Message))
+				fmt.Fprintf(&b, "      expected: %t\n", d.Expected)
+			}
+		}
+		fmt.Fprintln(&b, "  ...")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// yamlString quotes s as a YAML double-quoted scalar, whose escapes are
+// a superset of JSON's.
+func yamlString(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
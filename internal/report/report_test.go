@@ -0,0 +1,135 @@
+package report
+
+import (
+	"bytes"
+	"io"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"example/Hello/internal/expect"
+	"example/Hello/internal/lesson"
+	"example/Hello/internal/runner"
+)
+
+const root = "/m"
+
+func results() []runner.Result {
+	return []runner.Result{
+		{
+			Lesson:   lesson.Lesson{Day: 1, Dir: filepath.Join(root, "Day 1")},
+			Status:   runner.StatusOK,
+			Duration: 1500 * time.Microsecond,
+			Stdout:   "Hello <World> & \"you\"\n",
+		},
+		{
+			Lesson:   lesson.Lesson{Day: 2, Dir: filepath.Join(root, "Day 2")},
+			Status:   runner.StatusBuildFailed,
+			ExitCode: -1,
+			BuildOutput: "# m/Day 2\n" +
+				"./day2.go:7:2: undefined: x\n" +
+				"./day2.go:9:2: declared and not used: y\n" +
+				"unexpected error: day2.go:9:2: declared and not used: y\n",
+			Spec: expect.Spec{Regions: []expect.Region{{Start: 6, End: 8, Message: "undefined: x"}}},
+		},
+	}
+}
+
+func TestRecords(t *testing.T) {
+	recs := Records(root, results())
+	type diag struct {
+		File     string
+		Line     int
+		Expected bool
+	}
+	var got []diag
+	for _, r := range recs {
+		for _, d := range r.Diagnostics {
+			got = append(got, diag{d.File, d.Line, d.Expected})
+		}
+	}
+	want := []diag{
+		{"Day 2/day2.go", 7, true},
+		{"Day 2/day2.go", 9, false},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("diagnostics mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestWriters(t *testing.T) {
+	tests := []struct {
+		name  string
+		write func(io.Writer, []Record) error
+		want  string
+	}{
+		{
+			name:  "json",
+			write: JSON,
+			want: `{"lesson":"Day 1","day":1,"file":"Day 1/day1.go","status":"ok","passed":true,"exit_code":0,"duration_ms":1.5,"stdout":"Hello \u003cWorld\u003e \u0026 \"you\"\n"}
+{"lesson":"Day 2","day":2,"file":"Day 2/day2.go","status":"build-failed","passed":false,"exit_code":-1,"duration_ms":0,"diagnostics":[{"file":"Day 2/day2.go","line":7,"col":2,"message":"undefined: x","expected":true},{"file":"Day 2/day2.go","line":9,"col":2,"message":"declared and not used: y"}]}
+`,
+		},
+		{
+			name:  "junit",
+			write: JUnit,
+			want: `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="weeks" tests="2" failures="1" time="0.002">
+    <testcase name="Day 1" classname="weeks" file="Day 1/day1.go" time="0.002">
+      <system-out>Hello &lt;World&gt; &amp; &#34;you&#34;&#xA;</system-out>
+    </testcase>
+    <testcase name="Day 2" classname="weeks" file="Day 2/day2.go" time="0.000">
+      <failure message="build-failed (exit code -1)" type="build-failed">Day 2/day2.go:7:2: undefined: x&#xA;Day 2/day2.go:9:2: declared and not used: y&#xA;</failure>
+    </testcase>
+  </testsuite>
+</testsuites>
+`,
+		},
+		{
+			name:  "tap",
+			write: TAP,
+			want: `TAP version 13
+1..2
+ok 1 - Day 1
+  ---
+  file: "Day 1/day1.go"
+  status: ok
+  exit_code: 0
+  duration_ms: 1.500
+  ...
+not ok 2 - Day 2
+  ---
+  file: "Day 2/day2.go"
+  status: build-failed
+  exit_code: -1
+  duration_ms: 0.000
+  diagnostics:
+    - file: "Day 2/day2.go"
+      line: 7
+      column: 2
+      message: "undefined: x"
+      expected: true
+    - file: "Day 2/day2.go"
+      line: 9
+      column: 2
+      message: "declared and not used: y"
+      expected: false
+  ...
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := tt.write(&buf, Records(root, results())); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, buf.String()); diff != "" {
+				t.Errorf("output mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
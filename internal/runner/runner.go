@@ -0,0 +1,335 @@
+// Package runner builds and runs lessons.
+package runner
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"example/Hello/internal/diag"
+	"example/Hello/internal/expect"
+	"example/Hello/internal/lesson"
+)
+
+// Status is the outcome of running a lesson.
+type Status string
+
+const (
+	StatusOK          Status = "ok"
+	StatusBuildFailed Status = "build-failed"
+	StatusExitError   Status = "exit-error"
+
+	// StatusExpectedFail means the lesson failed to compile exactly as
+	// its weeks:expect-error markers said it would.
+	StatusExpectedFail Status = "xfail"
+	// StatusUnexpectedPass means a lesson marked as broken compiled.
+	StatusUnexpectedPass Status = "xpass"
+
+	// Limit violations, see Limits.
+	StatusTimeout     Status = "timeout"
+	StatusMemoryLimit Status = "memory-limit"
+	StatusOutputLimit Status = "output-limit"
+)
+
+// Result describes a single lesson run.
+type Result struct {
+	Lesson      lesson.Lesson
+	Status      Status
+	ExitCode    int
+	Duration    time.Duration // wall time of the lesson program
+	Stdout      string
+	Stderr      string
+	BuildOutput string
+	Expected    []string    // expected compiler errors that were confirmed
+	Spec        expect.Spec // the weeks:expect-error markers of the lesson
+}
+
+// Passed reports whether the lesson ran as expected.
+func (r Result) Passed() bool {
+	return r.Status == StatusOK || r.Status == StatusExpectedFail
+}
+
+// Runner builds lessons with the go tool and runs the resulting binaries.
+type Runner struct {
+	// Go is the go command to use. It defaults to "go".
+	Go string
+	// Limits bounds the resources of every lesson run.
+	Limits Limits
+	// CacheDir keeps compiled lessons between runs. If empty, lessons
+	// are built in a temporary directory.
+	CacheDir string
+	// Workers is the number of parallel builds. It defaults to GOMAXPROCS.
+	Workers int
+
+	envOnce sync.Once
+	env     string
+	envErr  error
+}
+
+func (r *Runner) goCmd() string {
+	if r.Go == "" {
+		return "go"
+	}
+	return r.Go
+}
+
+// Run builds and runs l.
+func (r *Runner) Run(ctx context.Context, l lesson.Lesson) Result {
+	return r.RunAll(ctx, []lesson.Lesson{l})[0]
+}
+
+// RunAll builds lessons in parallel and then runs them one at a time,
+// so that timings and output are not disturbed by other lessons.
+func (r *Runner) RunAll(ctx context.Context, lessons []lesson.Lesson) []Result {
+	dir := r.CacheDir
+	if dir == "" {
+		tmp, err := os.MkdirTemp("", "weeks-")
+		if err != nil {
+			return failAll(lessons, err)
+		}
+		defer os.RemoveAll(tmp)
+		dir = tmp
+	} else if err := os.MkdirAll(dir, 0o755); err != nil {
+		return failAll(lessons, err)
+	}
+
+	results := make([]Result, len(lessons))
+	bins := make([]string, len(lessons))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range r.workers() {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i], bins[i] = r.build(ctx, dir, lessons[i])
+			}
+		}()
+	}
+	for i := range lessons {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for i := range results {
+		if bins[i] != "" {
+			r.execute(ctx, bins[i], &results[i])
+		}
+	}
+	return results
+}
+
+func (r *Runner) workers() int {
+	if r.Workers > 0 {
+		return r.Workers
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+func failAll(lessons []lesson.Lesson, err error) []Result {
+	results := make([]Result, len(lessons))
+	for i, l := range lessons {
+		results[i] = Result{Lesson: l, Status: StatusBuildFailed, ExitCode: -1, BuildOutput: err.Error()}
+	}
+	return results
+}
+
+// build compiles l into dir. It returns the path of the binary to run,
+// or "" if the result is already final, e.g. because the build failed.
+func (r *Runner) build(ctx context.Context, dir string, l lesson.Lesson) (Result, string) {
+	res := Result{Lesson: l, ExitCode: -1}
+	fail := func(err error) (Result, string) {
+		res.Status = StatusBuildFailed
+		res.BuildOutput = err.Error()
+		return res, ""
+	}
+
+	src, err := os.ReadFile(l.Main())
+	if err != nil {
+		return fail(err)
+	}
+	spec, err := expect.Parse(src)
+	if err != nil {
+		return fail(fmt.Errorf("%s: %v", filepath.Base(l.Main()), err))
+	}
+	res.Spec = spec
+
+	if !spec.Empty() {
+		bin, out, err := r.compile(ctx, dir, l, src, false)
+		if err != nil && out == "" {
+			return fail(err)
+		}
+		res.BuildOutput = out
+		if bin != "" {
+			res.Status = StatusUnexpectedPass
+			res.BuildOutput = "lesson compiled but is marked with weeks:expect-error"
+			return res, ""
+		}
+		confirmed, problems := spec.Check(diag.Parse(out))
+		if len(problems) > 0 {
+			res.Status = StatusBuildFailed
+			res.BuildOutput += strings.Join(problems, "\n")
+			return res, ""
+		}
+		res.Expected = confirmed
+		if len(spec.Errors) > 0 {
+			res.Status = StatusExpectedFail
+			return res, ""
+		}
+		src = spec.Strip(src)
+	}
+
+	bin, out, err := r.compile(ctx, dir, l, src, !spec.Empty())
+	if bin == "" {
+		if out == "" {
+			return fail(err)
+		}
+		res.Status = StatusBuildFailed
+		res.BuildOutput = out
+		return res, ""
+	}
+	return res, bin
+}
+
+// compile builds src as the program of l and returns the binary, or the
+// compiler output if it does not compile. Both outcomes are cached in
+// dir under a key derived from src, go.mod, go.sum and the toolchain;
+// failures only if the compiler reported errors in the lesson. With
+// overlay set, src replaces the lesson's file on disk for the build.
+func (r *Runner) compile(ctx context.Context, dir string, l lesson.Lesson, src []byte, overlay bool) (bin, out string, err error) {
+	key, err := r.key(l, src)
+	if err != nil {
+		return "", "", err
+	}
+	bin = filepath.Join(dir, key)
+	if _, err := os.Stat(bin); err == nil {
+		return bin, "", nil
+	}
+	if b, err := os.ReadFile(bin + ".fail"); err == nil {
+		return "", string(b), nil
+	}
+
+	tmp, err := os.MkdirTemp(dir, "build-")
+	if err != nil {
+		return "", "", err
+	}
+	defer os.RemoveAll(tmp)
+
+	args := []string{"build", "-o", filepath.Join(tmp, "lesson")}
+	if overlay {
+		o, err := writeOverlay(tmp, l.Main(), src)
+		if err != nil {
+			return "", "", err
+		}
+		args = append(args, "-overlay", o)
+	}
+	args = append(args, filepath.Base(l.Main()))
+	cmd := exec.CommandContext(ctx, r.goCmd(), args...)
+	cmd.Dir = l.Dir
+	b, err := cmd.CombinedOutput()
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		if err := os.Rename(filepath.Join(tmp, "lesson"), bin); err != nil {
+			return "", "", err
+		}
+		return bin, "", nil
+	case errors.As(err, &exitErr) && ctx.Err() == nil:
+		// Remember the failure only if the compiler rejected the lesson
+		// itself; a broken environment, such as a failed module
+		// download, may be fixed by the next run.
+		if compilerError(l, string(b)) {
+			os.WriteFile(filepath.Join(tmp, "fail"), b, 0o644)
+			os.Rename(filepath.Join(tmp, "fail"), bin+".fail")
+		}
+		return "", string(b), nil
+	default:
+		return "", string(b), err
+	}
+}
+
+// compilerError reports whether out holds diagnostics of the compiler
+// for the program of l. The go command prints a "# package" header before
+// them; errors of its own, such as failed module lookups, have none.
+func compilerError(l lesson.Lesson, out string) bool {
+	if !strings.HasPrefix(out, "# ") && !strings.Contains(out, "\n# ") {
+		return false
+	}
+	for _, d := range diag.Parse(out) {
+		if filepath.Base(d.File) == filepath.Base(l.Main()) {
+			return true
+		}
+	}
+	return false
+}
+
+// key identifies a build of src for l with the current toolchain and
+// module requirements.
+func (r *Runner) key(l lesson.Lesson, src []byte) (string, error) {
+	r.envOnce.Do(func() {
+		out, err := exec.Command(r.goCmd(), "env", "GOVERSION", "GOOS", "GOARCH").Output()
+		r.env, r.envErr = string(out), err
+	})
+	if r.envErr != nil {
+		return "", fmt.Errorf("go env: %v", r.envErr)
+	}
+	modfile := findGoMod(l.Dir)
+	gomod, err := os.ReadFile(modfile)
+	if err != nil {
+		return "", err
+	}
+	gosum, err := os.ReadFile(strings.TrimSuffix(modfile, ".mod") + ".sum")
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return "", err
+	}
+	h := sha256.New()
+	for _, part := range [][]byte{[]byte(r.env), gomod, gosum, []byte(filepath.Base(l.Main())), src} {
+		fmt.Fprintf(h, "%d\n", len(part))
+		h.Write(part)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// findGoMod returns the go.mod governing dir.
+func findGoMod(dir string) string {
+	for {
+		p := filepath.Join(dir, "go.mod")
+		if _, err := os.Stat(p); err == nil {
+			return p
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return p
+		}
+		dir = parent
+	}
+}
+
+// writeOverlay stores src in dir as a replacement for file and returns
+// the path of the overlay description for go build -overlay.
+func writeOverlay(dir, file string, src []byte) (string, error) {
+	replacement := filepath.Join(dir, "overlay.go")
+	if err := os.WriteFile(replacement, src, 0o644); err != nil {
+		return "", err
+	}
+	b, err := json.Marshal(map[string]map[string]string{
+		"Replace": {file: replacement},
+	})
+	if err != nil {
+		return "", err
+	}
+	overlay := filepath.Join(dir, "overlay.json")
+	return overlay, os.WriteFile(overlay, b, 0o644)
+}
@@ -0,0 +1,148 @@
+package runner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"example/Hello/internal/lesson"
+)
+
+func TestMain(m *testing.M) {
+	// Memory limited lessons run under the test binary as a shim.
+	Init()
+	os.Exit(m.Run())
+}
+
+// writeLesson creates a module in a temporary directory holding day 1
+// with the program src.
+func writeLesson(t *testing.T, src string) lesson.Lesson {
+	t.Helper()
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte("module lessons\n\ngo 1.21\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	l := lesson.At(root, 1)
+	if err := os.MkdirAll(l.Dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(l.Main(), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return l
+}
+
+func TestLimits(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds lessons")
+	}
+	tests := []struct {
+		name   string
+		src    string
+		limits Limits
+		status Status
+	}{
+		{
+			name:   "ok",
+			src:    "package main\n\nimport \"fmt\"\n\nfunc main() { fmt.Println(\"hi\") }\n",
+			limits: Limits{Timeout: time.Minute, Output: 1 << 10},
+			status: StatusOK,
+		},
+		{
+			name:   "exit error",
+			src:    "package main\n\nimport \"os\"\n\nfunc main() { os.Exit(3) }\n",
+			status: StatusExitError,
+		},
+		{
+			name:   "loops forever",
+			src:    "package main\n\nfunc main() {\n\tfor {\n\t}\n}\n",
+			limits: Limits{Timeout: 500 * time.Millisecond},
+			status: StatusTimeout,
+		},
+		{
+			name:   "prints without end",
+			src:    "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfor {\n\t\tfmt.Println(\"again\")\n\t}\n}\n",
+			limits: Limits{Timeout: time.Minute, Output: 1 << 10},
+			status: StatusOutputLimit,
+		},
+		{
+			name:   "build failed",
+			src:    "package main\n\nfunc main() { x := 1 }\n",
+			status: StatusBuildFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Runner{Limits: tt.limits}
+			res := r.Run(context.Background(), writeLesson(t, tt.src))
+			if res.Status != tt.status {
+				t.Fatalf("status = %s, want %s\nstdout: %.200s\nstderr: %.200s\nbuild: %s", res.Status, tt.status, res.Stdout, res.Stderr, res.BuildOutput)
+			}
+			if n := int64(len(res.Stdout) + len(res.Stderr)); tt.limits.Output > 0 && n > tt.limits.Output {
+				t.Errorf("captured %d bytes, limit %d", n, tt.limits.Output)
+			}
+		})
+	}
+}
+
+func TestCacheHitSkipsBuild(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds lessons")
+	}
+	l := writeLesson(t, "package main\n\nimport \"fmt\"\n\nfunc main() { fmt.Println(\"cached\") }\n")
+	r := &Runner{CacheDir: t.TempDir()}
+	if res := r.Run(context.Background(), l); res.Status != StatusOK {
+		t.Fatalf("first run: %s %s", res.Status, res.BuildOutput)
+	}
+	// A go command that cannot run proves the second run does not build.
+	r.Go = filepath.Join(t.TempDir(), "no-go")
+	res := r.Run(context.Background(), l)
+	if res.Status != StatusOK || res.Stdout != "cached\n" {
+		t.Fatalf("cached run: %s %q %s", res.Status, res.Stdout, res.BuildOutput)
+	}
+
+	// A changed program misses the cache and needs the go command.
+	if err := os.WriteFile(l.Main(), []byte("package main\n\nfunc main() {}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if res := r.Run(context.Background(), l); res.Status != StatusBuildFailed {
+		t.Errorf("changed program: status = %s, want %s", res.Status, StatusBuildFailed)
+	}
+}
+
+func TestCompilerError(t *testing.T) {
+	l := lesson.Lesson{Day: 4, Dir: "/m/Day 4"}
+	tests := []struct {
+		out  string
+		want bool
+	}{
+		{"# lessons/Day 4\n./day4.go:5:2: declared and not used: x\n", true},
+		{"# lessons/Day 4\n./other.go:5:2: undefined: y\n", false},
+		{"day4.go:3:8: no required module provides package rsc.io/quote\n", false},
+		{"go: downloading rsc.io/quote v1.5.2\n", false},
+	}
+	for _, tt := range tests {
+		if got := compilerError(l, tt.out); got != tt.want {
+			t.Errorf("compilerError(%q) = %v, want %v", tt.out, got, tt.want)
+		}
+	}
+}
+
+func TestFailureCache(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds lessons")
+	}
+	l := writeLesson(t, "package main\n\nfunc main() { x := 1 }\n")
+	dir := t.TempDir()
+	r := &Runner{CacheDir: dir}
+	if res := r.Run(context.Background(), l); !strings.Contains(res.BuildOutput, "declared and not used") {
+		t.Fatalf("build output = %q", res.BuildOutput)
+	}
+	fails, _ := filepath.Glob(filepath.Join(dir, "*.fail"))
+	if len(fails) != 1 {
+		t.Errorf("cached failures = %v, want one", fails)
+	}
+}
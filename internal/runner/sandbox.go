@@ -0,0 +1,179 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Limits bounds the resources a lesson may use. A zero field means no limit.
+type Limits struct {
+	// Timeout is the wall-clock limit. The whole process group of the
+	// lesson is killed when it expires.
+	Timeout time.Duration
+	// Memory caps the data segment of the lesson in bytes. It is only
+	// enforced on Linux, and only by programs that call Init.
+	Memory uint64
+	// Output caps the captured stdout and stderr together, in bytes.
+	Output int64
+}
+
+// execute runs the lesson binary under r.Limits in a fresh working
+// directory and records the outcome in res.
+func (r *Runner) execute(ctx context.Context, bin string, res *Result) {
+	work, err := os.MkdirTemp("", "weeks-work-")
+	if err != nil {
+		res.Status = StatusExitError
+		res.Stderr = err.Error()
+		return
+	}
+	defer os.RemoveAll(work)
+
+	cmd, err := command(bin, r.Limits)
+	if err != nil {
+		res.Status = StatusExitError
+		res.Stderr = err.Error()
+		return
+	}
+	cmd.Dir = work
+	cmd.WaitDelay = time.Second
+	setProcessGroup(cmd)
+
+	g := &group{cmd: cmd}
+	out := &capture{limit: r.Limits.Output, kill: g.kill}
+	stdout, stderr := &captureWriter{c: out}, &captureWriter{c: out}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	start := time.Now()
+	if err := cmd.Start(); err != nil {
+		res.Status = StatusExitError
+		res.Stderr = err.Error()
+		return
+	}
+	done := make(chan error, 1)
+	go func() { done <- g.wait() }()
+
+	var timeout <-chan time.Time
+	if r.Limits.Timeout > 0 {
+		t := time.NewTimer(r.Limits.Timeout)
+		defer t.Stop()
+		timeout = t.C
+	}
+	timedOut := false
+	select {
+	case err = <-done:
+	case <-timeout:
+		timedOut = true
+		g.kill()
+		err = <-done
+	case <-ctx.Done():
+		g.kill()
+		err = <-done
+	}
+	if errors.Is(err, exec.ErrWaitDelay) {
+		err = nil
+	}
+	res.Duration = time.Since(start)
+	res.Stdout = stdout.buf.String()
+	res.Stderr = stderr.buf.String()
+
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		res.ExitCode = 0
+	case errors.As(err, &exitErr):
+		res.ExitCode = exitErr.ExitCode()
+	default:
+		res.Stderr += err.Error()
+	}
+	switch {
+	case timedOut:
+		res.Status = StatusTimeout
+	case err != nil && r.Limits.Memory > 0 && outOfMemory(res.Stderr):
+		res.Status = StatusMemoryLimit
+	case out.exceeded:
+		res.Status = StatusOutputLimit
+	case err != nil:
+		res.Status = StatusExitError
+	default:
+		res.Status = StatusOK
+	}
+}
+
+// group is the process group of a running lesson. It is only signalled
+// while the lesson's main process has not been reaped: afterwards its pid,
+// and so the group id, may belong to an unrelated process.
+type group struct {
+	mu     sync.Mutex
+	cmd    *exec.Cmd
+	exited bool
+}
+
+// kill kills the lesson and every process it started.
+func (g *group) kill() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if !g.exited {
+		killProcessGroup(g.cmd)
+	}
+}
+
+// wait waits for the lesson. Once its main process has exited, but before
+// it is reaped, anything the lesson left running in the background is
+// killed.
+func (g *group) wait() error {
+	waitExited(g.cmd)
+	g.mu.Lock()
+	killProcessGroup(g.cmd)
+	g.exited = true
+	g.mu.Unlock()
+	return g.cmd.Wait()
+}
+
+// outOfMemory reports whether stderr shows that the Go runtime of the
+// lesson could not get more memory.
+func outOfMemory(stderr string) bool {
+	return strings.Contains(stderr, "out of memory") ||
+		strings.Contains(stderr, "cannot allocate memory")
+}
+
+// capture counts the output of a lesson across stdout and stderr and
+// kills the lesson once the limit is exceeded.
+type capture struct {
+	mu       sync.Mutex
+	limit    int64
+	n        int64
+	exceeded bool
+	kill     func()
+}
+
+type captureWriter struct {
+	c   *capture
+	buf bytes.Buffer
+}
+
+func (w *captureWriter) Write(p []byte) (int, error) {
+	c := w.c
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.limit > 0 && c.n+int64(len(p)) > c.limit {
+		if keep := c.limit - c.n; keep > 0 {
+			w.buf.Write(p[:keep])
+			c.n += keep
+		}
+		if !c.exceeded {
+			c.exceeded = true
+			c.kill()
+		}
+		return len(p), nil
+	}
+	c.n += int64(len(p))
+	w.buf.Write(p)
+	return len(p), nil
+}
@@ -0,0 +1,84 @@
+package runner
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// shimEnv carries the memory limit to the re-executed runner process
+// that applies it before replacing itself with the lesson.
+const shimEnv = "WEEKS_SANDBOX_RLIMIT_DATA"
+
+// Init must be called at the start of main by programs that run lessons
+// with a memory limit. A Runner enforces that limit by starting the
+// current executable again as a shim; in that process Init applies the
+// rlimit and executes the lesson, so it never returns.
+func Init() {
+	v, ok := os.LookupEnv(shimEnv)
+	if !ok {
+		return
+	}
+	fail := func(err error) {
+		fmt.Fprintln(os.Stderr, "sandbox:", err)
+		os.Exit(126)
+	}
+	limit, err := strconv.ParseUint(v, 10, 64)
+	if err != nil {
+		fail(err)
+	}
+	if len(os.Args) < 2 {
+		fail(fmt.Errorf("no program to run"))
+	}
+	// RLIMIT_DATA rather than RLIMIT_AS: the Go runtime reserves large
+	// ranges of address space at startup and fails under a small
+	// RLIMIT_AS, while reserved but unused memory does not count as data.
+	rl := syscall.Rlimit{Cur: limit, Max: limit}
+	if err := syscall.Setrlimit(syscall.RLIMIT_DATA, &rl); err != nil {
+		fail(err)
+	}
+	os.Unsetenv(shimEnv)
+	fail(syscall.Exec(os.Args[1], os.Args[1:], os.Environ()))
+}
+
+// command returns the command that runs bin under lim.
+func command(bin string, lim Limits) (*exec.Cmd, error) {
+	if lim.Memory == 0 {
+		return exec.Command(bin), nil
+	}
+	self, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+	cmd := exec.Command(self, bin)
+	cmd.Env = append(os.Environ(), shimEnv+"="+strconv.FormatUint(lim.Memory, 10))
+	return cmd, nil
+}
+
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+// killProcessGroup kills the lesson and every process it started.
+func killProcessGroup(cmd *exec.Cmd) {
+	if cmd.Process == nil {
+		return
+	}
+	syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+}
+
+// waitExited blocks until the main process of cmd has exited, leaving it
+// unreaped so that its pid cannot be reused yet.
+func waitExited(cmd *exec.Cmd) {
+	var info unix.Siginfo
+	for {
+		err := unix.Waitid(unix.P_PID, cmd.Process.Pid, &info, unix.WEXITED|unix.WNOWAIT, nil)
+		if err != unix.EINTR {
+			return
+		}
+	}
+}
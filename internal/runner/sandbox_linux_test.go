@@ -0,0 +1,90 @@
+package runner
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestMemoryLimit(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds lessons")
+	}
+	l := writeLesson(t, `package main
+
+import "fmt"
+
+func main() {
+	var keep [][]byte
+	for {
+		b := make([]byte, 1<<20)
+		for i := range b {
+			b[i] = 1
+		}
+		keep = append(keep, b)
+		if len(keep)%1024 == 0 {
+			fmt.Println(len(keep), "MiB")
+		}
+	}
+}
+`)
+	r := &Runner{Limits: Limits{Timeout: time.Minute, Memory: 64 << 20}}
+	res := r.Run(context.Background(), l)
+	if res.Status != StatusMemoryLimit {
+		t.Fatalf("status = %s, want %s\nstdout: %.200s\nstderr: %.200s", res.Status, StatusMemoryLimit, res.Stdout, res.Stderr)
+	}
+}
+
+func TestProcessGroupKilled(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds lessons")
+	}
+	l := writeLesson(t, `package main
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+func main() {
+	cmd := exec.Command("sleep", "60")
+	if err := cmd.Start(); err != nil {
+		panic(err)
+	}
+	fmt.Println(cmd.Process.Pid)
+}
+`)
+	r := &Runner{Limits: Limits{Timeout: time.Minute}}
+	res := r.Run(context.Background(), l)
+	if res.Status != StatusOK {
+		t.Fatalf("status = %s, want %s\nstderr: %s", res.Status, StatusOK, res.Stderr)
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(res.Stdout))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The orphan is gone, or a zombie until init reaps it.
+	deadline := time.Now().Add(5 * time.Second)
+	for alive(pid) {
+		if time.Now().After(deadline) {
+			syscall.Kill(pid, syscall.SIGKILL)
+			t.Fatalf("background process %d of the lesson survived", pid)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func alive(pid int) bool {
+	b, err := os.ReadFile("/proc/" + strconv.Itoa(pid) + "/stat")
+	if err != nil {
+		return false
+	}
+	// The state follows the command name in parentheses.
+	s := string(b)
+	i := strings.LastIndexByte(s, ')')
+	return i < 0 || i+2 >= len(s) || s[i+2] != 'Z'
+}
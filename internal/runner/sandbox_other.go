@@ -0,0 +1,24 @@
+//go:build !linux
+
+package runner
+
+import "os/exec"
+
+// Init is a no-op: memory limits are only enforced on Linux.
+func Init() {}
+
+func command(bin string, lim Limits) (*exec.Cmd, error) {
+	return exec.Command(bin), nil
+}
+
+func setProcessGroup(cmd *exec.Cmd) {}
+
+func killProcessGroup(cmd *exec.Cmd) {
+	if cmd.Process != nil {
+		cmd.Process.Kill()
+	}
+}
+
+// waitExited does nothing: killProcessGroup only signals the process
+// itself, which is safe after it has been reaped.
+func waitExited(cmd *exec.Cmd) {}
@@ -0,0 +1,96 @@
+// Package scaffold creates the files of a new lesson from templates.
+package scaffold
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/BurntSushi/toml"
+
+	"example/Hello/internal/lesson"
+)
+
+//go:embed templates
+var templateFS embed.FS
+
+var templates = template.Must(template.New("").Funcs(template.FuncMap{"toml": tomlString}).ParseFS(templateFS, "templates/*.tmpl"))
+
+// tomlString quotes s as a TOML basic string.
+func tomlString(s string) (string, error) {
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(map[string]string{"s": s}); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(strings.TrimPrefix(buf.String(), "s = ")), nil
+}
+
+// Options selects the optional files of a new lesson.
+type Options struct {
+	Title    string // defaults to "Day N"
+	Golden   bool   // expected output for weeks verify
+	Exercise bool   // exercise.md
+	Meta     bool   // lesson.toml
+}
+
+// data is passed to the templates.
+type data struct {
+	Day   int
+	Title string
+	Date  string
+}
+
+// Create writes a new lesson for l.Day. It refuses to touch an existing
+// lesson directory, and removes the new one again if it cannot write all
+// files. It returns the files it created.
+func Create(l lesson.Lesson, opts Options) ([]string, error) {
+	if err := os.Mkdir(l.Dir, 0o755); err != nil {
+		if os.IsExist(err) {
+			return nil, fmt.Errorf("%s already exists", l.Name())
+		}
+		return nil, err
+	}
+	d := data{Day: l.Day, Title: opts.Title, Date: time.Now().Format(time.DateOnly)}
+	if strings.TrimSpace(d.Title) == "" {
+		d.Title = l.Name()
+	}
+	stem := strings.TrimSuffix(l.Main(), ".go")
+	files := []struct {
+		tmpl, path string
+		want       bool
+	}{
+		{"day.go.tmpl", l.Main(), true},
+		{"day.golden.tmpl", stem + ".golden", opts.Golden},
+		{"exercise.md.tmpl", filepath.Join(l.Dir, "exercise.md"), opts.Exercise},
+		{"lesson.toml.tmpl", filepath.Join(l.Dir, "lesson.toml"), opts.Meta},
+	}
+	var created []string
+	for _, f := range files {
+		if !f.want {
+			continue
+		}
+		if err := write(f.path, f.tmpl, d); err != nil {
+			os.RemoveAll(l.Dir)
+			return nil, err
+		}
+		created = append(created, f.path)
+	}
+	return created, nil
+}
+
+func write(path, tmpl string, d data) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return err
+	}
+	if err := templates.ExecuteTemplate(f, tmpl, d); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
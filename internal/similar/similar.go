@@ -0,0 +1,111 @@
+// Package similar compares lessons after normalizing away everything a
+// copy-paste would change: string literals, comments and names.
+package similar
+
+import (
+	"fmt"
+	"go/ast"
+	"go/scanner"
+	"go/token"
+	"reflect"
+)
+
+// Tokens returns the token stream of src with comments removed and
+// every identifier and literal replaced by its kind.
+func Tokens(src []byte) []string {
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(src))
+	var s scanner.Scanner
+	s.Init(file, src, nil, 0)
+	var toks []string
+	for {
+		_, tok, lit := s.Scan()
+		switch {
+		case tok == token.EOF:
+			return toks
+		case tok == token.SEMICOLON && lit == "\n":
+			// Automatically inserted; layout is not content.
+		default:
+			// For identifiers and literals String is the kind,
+			// e.g. "IDENT" or "STRING", not the text.
+			toks = append(toks, tok.String())
+		}
+	}
+}
+
+// Shape returns the node types of f in preorder, e.g. "*ast.CallExpr".
+// Operators and declaration keywords are kept, names are not.
+func Shape(f *ast.File) []string {
+	var shape []string
+	ast.Inspect(f, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case nil:
+			return false
+		case *ast.CommentGroup, *ast.Comment:
+			return false
+		case *ast.BinaryExpr:
+			shape = append(shape, fmt.Sprintf("Binary(%s)", n.Op))
+		case *ast.AssignStmt:
+			shape = append(shape, fmt.Sprintf("Assign(%s)", n.Tok))
+		case *ast.GenDecl:
+			shape = append(shape, fmt.Sprintf("GenDecl(%s)", n.Tok))
+		default:
+			shape = append(shape, reflect.TypeOf(n).Elem().Name())
+		}
+		return true
+	})
+	return shape
+}
+
+// Ratio returns 2*LCS(a, b) / (len(a)+len(b)): 1 for identical
+// sequences and 0 for sequences with nothing in common.
+func Ratio(a, b []string) float64 {
+	if len(a)+len(b) == 0 {
+		return 1
+	}
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			switch {
+			case a[i-1] == b[j-1]:
+				cur[j] = prev[j-1] + 1
+			case prev[j] >= cur[j-1]:
+				cur[j] = prev[j]
+			default:
+				cur[j] = cur[j-1]
+			}
+		}
+		prev, cur = cur, prev
+	}
+	return 2 * float64(prev[len(b)]) / float64(len(a)+len(b))
+}
+
+// Lesson is a lesson prepared for comparison.
+type Lesson struct {
+	Day    int
+	Tokens []string
+	Shape  []string
+}
+
+// Pair is the similarity of two lessons, A before B.
+type Pair struct {
+	A, B   int
+	Tokens float64 // similarity of the token streams
+	Shape  float64 // similarity of the AST shapes
+	Score  float64 // mean of Tokens and Shape
+}
+
+// Compare returns the similarity of every pair of lessons.
+func Compare(lessons []Lesson) []Pair {
+	var pairs []Pair
+	for i := range lessons {
+		for j := i + 1; j < len(lessons); j++ {
+			a, b := lessons[i], lessons[j]
+			p := Pair{A: a.Day, B: b.Day, Tokens: Ratio(a.Tokens, b.Tokens), Shape: Ratio(a.Shape, b.Shape)}
+			p.Score = (p.Tokens + p.Shape) / 2
+			pairs = append(pairs, p)
+		}
+	}
+	return pairs
+}
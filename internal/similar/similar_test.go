@@ -0,0 +1,81 @@
+package similar
+
+import (
+	"go/parser"
+	"go/token"
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTokens(t *testing.T) {
+	tests := []struct {
+		src  string
+		want []string
+	}{
+		{"x := 5", []string{"IDENT", ":=", "INT"}},
+		{"fmt.Println(\"hi\") // greet\n", []string{"IDENT", ".", "IDENT", "(", "STRING", ")"}},
+		{"var a, b = 1.5, 'c'", []string{"var", "IDENT", ",", "IDENT", "=", "FLOAT", ",", "CHAR"}},
+		{"/* only a comment */", nil},
+	}
+	for _, tt := range tests {
+		if diff := cmp.Diff(tt.want, Tokens([]byte(tt.src))); diff != "" {
+			t.Errorf("Tokens(%q) mismatch (-want +got):\n%s", tt.src, diff)
+		}
+	}
+}
+
+func TestShapeIgnoresNames(t *testing.T) {
+	parse := func(src string) []string {
+		f, err := parser.ParseFile(token.NewFileSet(), "", src, parser.ParseComments)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return Shape(f)
+	}
+	a := parse("package main\n\nfunc main() {\n\tx := 1 + 2 // sum\n}\n")
+	b := parse("package main\n\nfunc main() {\n\ttotal := 7 + 9\n}\n")
+	c := parse("package main\n\nfunc main() {\n\tx := 1 - 2\n}\n")
+	if diff := cmp.Diff(a, b); diff != "" {
+		t.Errorf("renamed lesson has a different shape (-a +b):\n%s", diff)
+	}
+	if cmp.Equal(a, c) {
+		t.Error("changing the operator did not change the shape")
+	}
+}
+
+func TestRatio(t *testing.T) {
+	tests := []struct {
+		a, b []string
+		want float64
+	}{
+		{nil, nil, 1},
+		{[]string{"a", "b"}, []string{"a", "b"}, 1},
+		{[]string{"a", "b"}, []string{"c", "d"}, 0},
+		{[]string{"a", "b", "c", "d"}, []string{"a", "c"}, 2 * 2.0 / 6},
+		{[]string{"a"}, nil, 0},
+	}
+	for _, tt := range tests {
+		if got := Ratio(tt.a, tt.b); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("Ratio(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	lessons := []Lesson{
+		{Day: 1, Tokens: []string{"a", "b"}, Shape: []string{"x"}},
+		{Day: 2, Tokens: []string{"a", "b"}, Shape: []string{"y"}},
+		{Day: 3, Tokens: []string{"a"}, Shape: []string{"x"}},
+	}
+	want := []Pair{
+		{A: 1, B: 2, Tokens: 1, Shape: 0, Score: 0.5},
+		{A: 1, B: 3, Tokens: 2.0 / 3, Shape: 1, Score: (2.0/3 + 1) / 2},
+		{A: 2, B: 3, Tokens: 2.0 / 3, Shape: 0, Score: 1.0 / 3},
+	}
+	approx := cmp.Comparer(func(x, y float64) bool { return math.Abs(x-y) < 1e-9 })
+	if diff := cmp.Diff(want, Compare(lessons), approx); diff != "" {
+		t.Errorf("Compare mismatch (-want +got):\n%s", diff)
+	}
+}
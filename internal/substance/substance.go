@@ -0,0 +1,95 @@
+// Package substance scores how much a lesson contains, to tell real
+// lessons from placeholder days.
+package substance
+
+import (
+	"go/ast"
+	"strings"
+
+	"example/Hello/internal/check"
+)
+
+// Factors are the measurements behind a score.
+type Factors struct {
+	Nodes        int     `json:"nodes"`         // AST nodes, without comments
+	Concepts     int     `json:"concepts"`      // distinct concepts used
+	CommentRatio float64 `json:"comment_ratio"` // comment lines per code line
+	Novel        int     `json:"novel"`         // concepts no earlier lesson used
+}
+
+// Score is the substance of a lesson from 0 to 100.
+type Score struct {
+	Day     int     `json:"day"`
+	Value   float64 `json:"score"`
+	Factors Factors `json:"factors"`
+}
+
+// Each factor adds its weight to the score once it reaches its target;
+// below the target it adds proportionally less.
+var factors = []struct {
+	weight, target float64
+	value          func(Factors) float64
+}{
+	{30, 150, func(f Factors) float64 { return float64(f.Nodes) }},
+	{30, 15, func(f Factors) float64 { return float64(f.Concepts) }},
+	{15, 0.3, func(f Factors) float64 { return f.CommentRatio }},
+	{25, 5, func(f Factors) float64 { return float64(f.Novel) }},
+}
+
+// Compute scores a lesson from its factors.
+func Compute(day int, f Factors) Score {
+	s := Score{Day: day, Factors: f}
+	for _, fc := range factors {
+		s.Value += fc.weight * min(fc.value(f)/fc.target, 1)
+	}
+	return s
+}
+
+// Measure counts the nodes and the comment ratio of a lesson. The
+// concept counts come from the concepts package.
+func Measure(p *check.Package) Factors {
+	var f Factors
+	if p.File == nil {
+		return f
+	}
+	ast.Inspect(p.File, func(n ast.Node) bool {
+		switch n.(type) {
+		case nil:
+			return false
+		case *ast.CommentGroup, *ast.Comment:
+			return false
+		}
+		f.Nodes++
+		return true
+	})
+
+	commentLines := make(map[int]bool)
+	for _, cg := range p.File.Comments {
+		for _, c := range cg.List {
+			if strings.HasPrefix(c.Text, "// weeks:") {
+				continue
+			}
+			start, end := p.Fset.Position(c.Pos()).Line, p.Fset.Position(c.End()).Line
+			for l := start; l <= end; l++ {
+				commentLines[l] = true
+			}
+		}
+	}
+	codeLines := make(map[int]bool)
+	ast.Inspect(p.File, func(n ast.Node) bool {
+		if n == nil {
+			return false
+		}
+		switch n.(type) {
+		case *ast.CommentGroup, *ast.Comment:
+			return false
+		case *ast.Ident, *ast.BasicLit, *ast.BranchStmt:
+			codeLines[p.Fset.Position(n.Pos()).Line] = true
+		}
+		return true
+	})
+	if len(codeLines) > 0 {
+		f.CommentRatio = float64(len(commentLines)) / float64(len(codeLines))
+	}
+	return f
+}
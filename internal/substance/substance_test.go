@@ -0,0 +1,56 @@
+package substance
+
+import (
+	"math"
+	"testing"
+
+	"example/Hello/internal/check"
+)
+
+func TestCompute(t *testing.T) {
+	tests := []struct {
+		name string
+		f    Factors
+		want float64
+	}{
+		{"empty", Factors{}, 0},
+		{"every target reached", Factors{Nodes: 150, Concepts: 15, CommentRatio: 0.3, Novel: 5}, 100},
+		{"targets saturate", Factors{Nodes: 1000, Concepts: 99, CommentRatio: 3, Novel: 50}, 100},
+		{"half of everything", Factors{Nodes: 75, Concepts: 7, CommentRatio: 0.15, Novel: 2}, 15 + 30*7.0/15 + 7.5 + 10},
+		{"only novel concepts", Factors{Novel: 5}, 25},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := Compute(4, tt.f)
+			if math.Abs(s.Value-tt.want) > 1e-9 {
+				t.Errorf("Compute(%+v) = %v, want %v", tt.f, s.Value, tt.want)
+			}
+			if s.Day != 4 || s.Factors != tt.f {
+				t.Errorf("Compute did not keep day and factors: %+v", s)
+			}
+		})
+	}
+}
+
+func TestMeasure(t *testing.T) {
+	tests := []struct {
+		name      string
+		src       string
+		wantRatio float64
+	}{
+		{"no comments", "package main\n\nfunc main() {\n\tx := 1\n\t_ = x\n}\n", 0},
+		{"one comment per code line", "package main // the package\n\nfunc main() { // entry\n}\n", 1},
+		{"markers do not count", "package main\n\n// weeks:expect-error undefined: x\nfunc main() {}\n", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := Measure(check.Source("day.go", []byte(tt.src)))
+			if f.Nodes == 0 {
+				t.Error("no nodes counted")
+			}
+			if math.Abs(f.CommentRatio-tt.wantRatio) > 1e-9 {
+				t.Errorf("CommentRatio = %v, want %v", f.CommentRatio, tt.wantRatio)
+			}
+		})
+	}
+}
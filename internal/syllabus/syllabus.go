@@ -0,0 +1,182 @@
+// Package syllabus reads the 52-week plan of the journal and compares it
+// with the lessons on disk.
+package syllabus
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// FileName is the name of the syllabus at the module root.
+const FileName = "syllabus.toml"
+
+// Weeks is the length of the journal.
+const Weeks = 52
+
+// Syllabus is the plan of the journal.
+type Syllabus struct {
+	Weeks []Week `toml:"week"`
+}
+
+// Week is a planned week. Days lists the days that are planned one by
+// one; a week without days only has a theme and topics so far.
+type Week struct {
+	Week   int      `toml:"week"`
+	Theme  string   `toml:"theme"`
+	Topics []string `toml:"topics"`
+	Days   []Day    `toml:"day"`
+}
+
+// Day is a planned day.
+type Day struct {
+	Day    int      `toml:"day"`
+	Topics []string `toml:"topics"`
+}
+
+// WeekOf returns the week that day belongs to.
+func WeekOf(day int) int {
+	return (day-1)/7 + 1
+}
+
+// Load reads and validates the syllabus at path.
+func Load(path string) (*Syllabus, error) {
+	var s Syllabus
+	md, err := toml.DecodeFile(path, &s)
+	if err != nil {
+		return nil, err
+	}
+	if keys := md.Undecoded(); len(keys) > 0 {
+		return nil, fmt.Errorf("%s: unknown key %q", path, keys[0].String())
+	}
+	if err := s.validate(); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return &s, nil
+}
+
+func (s *Syllabus) validate() error {
+	weeks := make(map[int]bool)
+	days := make(map[int]bool)
+	for _, w := range s.Weeks {
+		if w.Week < 1 || w.Week > Weeks {
+			return fmt.Errorf("week %d is outside 1-%d", w.Week, Weeks)
+		}
+		if weeks[w.Week] {
+			return fmt.Errorf("week %d is planned twice", w.Week)
+		}
+		weeks[w.Week] = true
+		for _, d := range w.Days {
+			if WeekOf(d.Day) != w.Week || d.Day < 1 {
+				return fmt.Errorf("day %d does not belong to week %d", d.Day, w.Week)
+			}
+			if days[d.Day] {
+				return fmt.Errorf("day %d is planned twice", d.Day)
+			}
+			days[d.Day] = true
+		}
+	}
+	return nil
+}
+
+// Day returns the plan for day, if it has one.
+func (s *Syllabus) Day(day int) (Day, bool) {
+	for _, w := range s.Weeks {
+		for _, d := range w.Days {
+			if d.Day == day {
+				return d, true
+			}
+		}
+	}
+	return Day{}, false
+}
+
+// Report compares the syllabus with the lessons on disk. Missing lists
+// the days up to the last lesson that have no lesson, and Unplanned the
+// lessons whose day has no planned topic.
+type Report struct {
+	LastDay     int       `json:"last_day"`
+	Lessons     int       `json:"lessons"`
+	Coverage    float64   `json:"coverage"` // share of due topics practiced
+	Missing     []int     `json:"missing_days"`
+	Unplanned   []int     `json:"unplanned_days"`
+	Unpracticed []Planned `json:"unpracticed_topics"`
+}
+
+// Planned is a topic planned for a day, or only for a week if Day is 0.
+type Planned struct {
+	Topic string `json:"topic"`
+	Week  int    `json:"week"`
+	Day   int    `json:"day,omitempty"`
+}
+
+// Compare builds a report from practiced, which maps the day of every
+// lesson on disk to the topics it practiced. Only days up to the last
+// lesson are due; later days are still to come. The topics of a week
+// that are not planned for one of its days are due from the week of the
+// last lesson on.
+func (s *Syllabus) Compare(practiced map[int][]string) Report {
+	r := Report{Missing: []int{}, Unplanned: []int{}, Unpracticed: []Planned{}}
+	done := make(map[string]bool)
+	for day, topics := range practiced {
+		r.Lessons++
+		r.LastDay = max(r.LastDay, day)
+		for _, t := range topics {
+			done[strings.ToLower(t)] = true
+		}
+	}
+
+	due, covered := 0, 0
+	count := func(p Planned) {
+		due++
+		if done[strings.ToLower(p.Topic)] {
+			covered++
+		} else {
+			r.Unpracticed = append(r.Unpracticed, p)
+		}
+	}
+	for _, w := range s.Weeks {
+		if r.LastDay == 0 || w.Week > WeekOf(r.LastDay) {
+			continue
+		}
+		daily := make(map[string]bool)
+		for _, d := range w.Days {
+			for _, t := range d.Topics {
+				daily[strings.ToLower(t)] = true
+				if d.Day <= r.LastDay {
+					count(Planned{Topic: t, Week: w.Week, Day: d.Day})
+				}
+			}
+		}
+		for _, t := range w.Topics {
+			if !daily[strings.ToLower(t)] {
+				count(Planned{Topic: t, Week: w.Week})
+			}
+		}
+	}
+	for day := 1; day <= r.LastDay; day++ {
+		if _, ok := practiced[day]; !ok {
+			r.Missing = append(r.Missing, day)
+		}
+	}
+	for day := range practiced {
+		if d, ok := s.Day(day); !ok || len(d.Topics) == 0 {
+			r.Unplanned = append(r.Unplanned, day)
+		}
+	}
+	if due > 0 {
+		r.Coverage = float64(covered) / float64(due)
+	}
+	sort.Ints(r.Unplanned)
+	sort.SliceStable(r.Unpracticed, func(i, j int) bool {
+		a, b := r.Unpracticed[i], r.Unpracticed[j]
+		if a.Week != b.Week {
+			return a.Week < b.Week
+		}
+		// Topics of the whole week come after its days.
+		return a.Day != 0 && (b.Day == 0 || a.Day < b.Day)
+	})
+	return r
+}
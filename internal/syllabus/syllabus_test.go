@@ -0,0 +1,107 @@
+package syllabus
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWeekOf(t *testing.T) {
+	for day, want := range map[int]int{1: 1, 7: 1, 8: 2, 14: 2, 15: 3, 364: 52} {
+		if got := WeekOf(day); got != want {
+			t.Errorf("WeekOf(%d) = %d, want %d", day, got, want)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name string
+		toml string
+		err  string
+	}{
+		{"ok", "[[week]]\nweek = 1\ntopics = [\"a\"]\n[[week.day]]\nday = 2\ntopics = [\"b\"]\n", ""},
+		{"unknown key", "[[week]]\nweek = 1\ntopic = [\"a\"]\n", `unknown key "week.topic"`},
+		{"week out of range", "[[week]]\nweek = 53\n", "week 53 is outside 1-52"},
+		{"week twice", "[[week]]\nweek = 1\n[[week]]\nweek = 1\n", "week 1 is planned twice"},
+		{"day in wrong week", "[[week]]\nweek = 1\n[[week.day]]\nday = 8\n", "day 8 does not belong to week 1"},
+		{"day twice", "[[week]]\nweek = 1\n[[week.day]]\nday = 3\n[[week.day]]\nday = 3\n", "day 3 is planned twice"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), FileName)
+			if err := os.WriteFile(path, []byte(tt.toml), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			_, err := Load(path)
+			switch {
+			case tt.err == "" && err != nil:
+				t.Errorf("Load: %v", err)
+			case tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)):
+				t.Errorf("Load: error %v, want %q", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	s := &Syllabus{Weeks: []Week{
+		{Week: 1, Topics: []string{"Setup", "constants"}, Days: []Day{
+			{Day: 1, Topics: []string{"setup"}},
+			{Day: 2, Topics: []string{"hello-world", "imports"}},
+		}},
+		{Week: 2, Topics: []string{"loops", "slices"}},
+		{Week: 3, Topics: []string{"maps"}},
+	}}
+	tests := []struct {
+		name      string
+		practiced map[int][]string
+		want      Report
+	}{
+		{
+			name:      "nothing yet",
+			practiced: map[int][]string{},
+			want:      Report{Missing: []int{}, Unplanned: []int{}, Unpracticed: []Planned{}},
+		},
+		{
+			name:      "first week",
+			practiced: map[int][]string{1: {"SETUP"}, 2: {"hello-world"}},
+			want: Report{
+				LastDay:   2,
+				Lessons:   2,
+				Coverage:  2.0 / 4,
+				Missing:   []int{},
+				Unplanned: []int{},
+				Unpracticed: []Planned{
+					{Topic: "imports", Week: 1, Day: 2},
+					{Topic: "constants", Week: 1},
+				},
+			},
+		},
+		{
+			name:      "week planned only as a whole",
+			practiced: map[int][]string{1: {"setup"}, 2: {"imports", "constants"}, 9: {"loops"}},
+			want: Report{
+				LastDay:   9,
+				Lessons:   3,
+				Coverage:  4.0 / 6,
+				Missing:   []int{3, 4, 5, 6, 7, 8},
+				Unplanned: []int{9},
+				Unpracticed: []Planned{
+					{Topic: "hello-world", Week: 1, Day: 2},
+					{Topic: "slices", Week: 2},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, s.Compare(tt.practiced)); diff != "" {
+				t.Errorf("report mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package lessons
+
+import (
+	"fmt"
+	"io"
+)
+
+func init() {
+	Register(Lesson{Day: 2, Name: "Hello World", Run: day2})
+}
+
+// day2 leaves out the "x = 5" line of Day 2/day2.go, which does not
+// compile because x is never declared.
+func day2(w io.Writer) error {
+	_, err := fmt.Fprintln(w, "Hello World!")
+	return err
+}
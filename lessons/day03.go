@@ -0,0 +1,18 @@
+package lessons
+
+import (
+	"fmt"
+	"io"
+)
+
+func init() {
+	Register(Lesson{Day: 3, Name: "Comments", Run: day3})
+}
+
+func day3(w io.Writer) error {
+	//this line is a comment
+	/* this line is a multi comment
+	lol ok */
+	_, err := fmt.Fprintln(w, "Test")
+	return err
+}
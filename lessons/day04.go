@@ -0,0 +1,39 @@
+package lessons
+
+import (
+	"fmt"
+	"io"
+)
+
+func init() {
+	Register(Lesson{Day: 4, Name: "Variables", Run: day4})
+}
+
+func day4(w io.Writer) error {
+	var x = 5
+	var z = 55
+	fmt.Fprintln(w, x)
+	fmt.Fprintln(w, z)
+	fmt.Fprintln(w, x+z)
+	// x + z
+	z = x
+	fmt.Fprintln(w, x)
+	fmt.Fprintln(w, z)
+	fmt.Fprintln(w, x+z)
+
+	// the above is a wrong code
+	// you declare using :=  (that is shorthand decalaration where go automatically assumes the type of the data)
+	// := can only be used inside functions while var can be used anywhere
+	// := must assign a value, while var can declare without value
+
+	name := "Vincent"
+	age := 18
+	var nameclone string = "Tnecniv"
+	var nameclone2 string = "vince"
+	// this doesnt work nameclone3 = "tete"
+	var nameclone3 = "hahah"
+
+	fmt.Fprintln(w, name, age, nameclone, nameclone2, nameclone3)
+	_, err := fmt.Fprintln(w, name, age, nameclone, nameclone2+nameclone3) // ooh so add concatenates the screen
+	return err
+}
@@ -0,0 +1,24 @@
+package lessons
+
+import (
+	"fmt"
+	"io"
+)
+
+func init() {
+	Register(Lesson{Day: 5, Name: "Declaring multiple variables", Run: day5})
+}
+
+func day5(w io.Writer) error {
+	var x, y, z = 10, 20, 30
+
+	fmt.Fprintln(w, "x+y =", x+y)
+	fmt.Fprintln(w, z)
+
+	// can declare diff types if not specified
+
+	var s, ints = "string", 15
+
+	_, err := fmt.Fprintln(w, s, ints)
+	return err
+}
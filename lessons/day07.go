@@ -0,0 +1,15 @@
+package lessons
+
+import (
+	"fmt"
+	"io"
+)
+
+func init() {
+	Register(Lesson{Day: 7, Name: "Forgot to push", Run: day7})
+}
+
+func day7(w io.Writer) error {
+	_, err := fmt.Fprintln(w, "I FORGOT TO PUSH")
+	return err
+}
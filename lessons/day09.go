@@ -0,0 +1,17 @@
+package lessons
+
+import (
+	"fmt"
+	"io"
+)
+
+func init() {
+	Register(Lesson{Day: 9, Name: "Constants", Run: day9})
+}
+
+const PI = 3.14
+
+func day9(w io.Writer) error {
+	_, err := fmt.Fprintln(w, PI)
+	return err
+}
@@ -0,0 +1,51 @@
+// Package lessons holds the daily lessons of the journal as code that can
+// be imported and run in-process.
+//
+// Each day registers itself from an init function:
+//
+//	func init() {
+//		Register(Lesson{Day: 9, Name: "Constants", Run: day9})
+//	}
+package lessons
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// Lesson is a registered day of the journal.
+type Lesson struct {
+	Day  int
+	Name string
+	Run  func(w io.Writer) error
+}
+
+var registry = make(map[int]Lesson)
+
+// Register adds l to the registry. It panics if the day is already taken.
+func Register(l Lesson) {
+	if _, dup := registry[l.Day]; dup {
+		panic(fmt.Sprintf("lessons: day %d registered twice", l.Day))
+	}
+	if l.Run == nil {
+		panic(fmt.Sprintf("lessons: day %d has no Run function", l.Day))
+	}
+	registry[l.Day] = l
+}
+
+// All returns the registered lessons sorted by day.
+func All() []Lesson {
+	all := make([]Lesson, 0, len(registry))
+	for _, l := range registry {
+		all = append(all, l)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Day < all[j].Day })
+	return all
+}
+
+// Lookup returns the lesson of the given day.
+func Lookup(day int) (Lesson, bool) {
+	l, ok := registry[day]
+	return l, ok
+}
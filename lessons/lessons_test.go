@@ -0,0 +1,56 @@
+package lessons
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"example/Hello/internal/golden"
+	"example/Hello/internal/lesson"
+)
+
+// TestGolden runs every registered lesson and compares its output with
+// the golden file of the lesson directory.
+func TestGolden(t *testing.T) {
+	all, err := lesson.Discover("..")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(All()) == 0 {
+		t.Fatal("no lessons registered")
+	}
+	for _, l := range All() {
+		t.Run(fmt.Sprintf("Day %d", l.Day), func(t *testing.T) {
+			dirs, err := lesson.Select(all, []int{l.Day})
+			if err != nil {
+				t.Fatal(err)
+			}
+			want, ok, err := golden.Read(dirs[0])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !ok {
+				t.Skipf("%s has no golden file", dirs[0].Name())
+			}
+			var buf bytes.Buffer
+			if err := l.Run(&buf); err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+			if diff := golden.Diff(want, buf.String()); diff != "" {
+				t.Errorf("output mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	for _, l := range All() {
+		got, ok := Lookup(l.Day)
+		if !ok || got.Name != l.Name {
+			t.Errorf("Lookup(%d) = %q, %v; want %q", l.Day, got.Name, ok, l.Name)
+		}
+	}
+	if _, ok := Lookup(0); ok {
+		t.Error("Lookup(0) found a lesson")
+	}
+}